@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -22,6 +24,8 @@ func main() {
 		wpTag        string
 		guidesDir    string
 		overridesDir string
+		reportPath   string
+		hideInternal bool
 		skipJS       bool
 		skipPHP      bool
 		workers      int
@@ -81,14 +85,19 @@ suitable for developer.wordpress.org.`,
 			log.Println("Resolving cross-references...")
 			res := resolver.New(registry)
 			res.ResolveAll()
-			log.Printf("Resolved %d cross-references", res.Stats().Resolved)
+			log.Printf("Resolved %d cross-references (%d call edges, %d unresolved)",
+				res.Stats().Resolved, res.Stats().CallEdges, res.Stats().Unresolved)
 
 			// Step 5: Generate Hugo site
 			log.Printf("Generating Hugo site in %s", outDir)
 			gen := output.NewHugo(outDir, src.Path, src.Version, guidesDir, overridesDir)
+			gen.SetHideInternal(hideInternal)
 			if err := gen.Generate(registry); err != nil {
 				return fmt.Errorf("generating output: %w", err)
 			}
+			if err := writeReport(reportPath, res.Issues()); err != nil {
+				return fmt.Errorf("writing report: %w", err)
+			}
 
 			log.Printf("Done in %s. Total symbols: %d",
 				time.Since(start).Round(time.Millisecond),
@@ -102,6 +111,8 @@ suitable for developer.wordpress.org.`,
 	root.Flags().StringVarP(&wpTag, "tag", "t", "latest", "WordPress version tag (e.g., 6.7.1)")
 	root.Flags().StringVarP(&guidesDir, "guides", "g", "./content/guides", "Path to guide markdown files (_shared/ + version dirs)")
 	root.Flags().StringVar(&overridesDir, "overrides", "./content/overrides", "Path to override markdown files (_shared/ + version dirs)")
+	root.Flags().StringVar(&reportPath, "report", "", "Write documentation issues found during resolution to this file")
+	root.Flags().BoolVar(&hideInternal, "hide-internal", false, "Leave symbols marked @ignore or @internal out of the generated site")
 	root.Flags().BoolVar(&skipJS, "skip-js", false, "Skip JS/TS parsing")
 	root.Flags().BoolVar(&skipPHP, "skip-php", false, "Skip PHP parsing")
 	root.Flags().IntVarP(&workers, "workers", "w", 8, "Number of parallel workers")
@@ -110,3 +121,38 @@ suitable for developer.wordpress.org.`,
 		os.Exit(1)
 	}
 }
+
+// writeReport logs a summary of resolver issues and, if path is set, writes
+// the full list to that file, one issue per line.
+func writeReport(path string, issues []resolver.Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, issue := range issues {
+		counts[issue.Kind]++
+	}
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		log.Printf("Found %d %s issues", counts[kind], kind)
+	}
+
+	if path == "" {
+		log.Println("Run with --report <file> for details")
+		return nil
+	}
+	var b strings.Builder
+	for _, issue := range issues {
+		b.WriteString(issue.String())
+		b.WriteByte('\n')
+	}
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		return err
+	}
+	log.Printf("Wrote %d issues to %s", len(issues), path)
+	return nil
+}
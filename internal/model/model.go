@@ -1,6 +1,8 @@
 package model
 
 import (
+	"sort"
+	"strings"
 	"sync"
 )
 
@@ -16,8 +18,11 @@ const (
 	KindInterface SymbolKind = "interface"
 	KindTrait     SymbolKind = "trait"
 	KindEnum      SymbolKind = "enum"
+	KindEnumCase  SymbolKind = "enum_case"
 	KindHook      SymbolKind = "hook"
 	KindComponent SymbolKind = "component" // React components in Gutenberg
+	KindGlobal    SymbolKind = "global"    // Global variables, e.g. $wpdb
+	KindTypeAlias SymbolKind = "type"      // TypeScript type aliases and JSDoc @typedef/@callback types
 )
 
 // HookType distinguishes actions from filters.
@@ -28,21 +33,176 @@ const (
 	HookFilter HookType = "filter"
 )
 
+// HookCallback records a callback registration against a hook, e.g.
+// add_action( 'init', 'wp_cron', 20 ).
+type HookCallback struct {
+	Tag          string `json:"tag"`
+	Function     string `json:"function"`                // add_action, add_filter, remove_action, has_filter, ...
+	Callback     string `json:"callback,omitempty"`      // e.g. "wp_cron", "WP_Query::init", "{closure}"
+	CallbackID   string `json:"callback_id,omitempty"`   // Resolved symbol ID (populated by resolver)
+	Priority     string `json:"priority,omitempty"`      // Literal priority expression, "10" by default
+	AcceptedArgs string `json:"accepted_args,omitempty"` // Literal accepted-args expression, "1" by default
+	CallerID     string `json:"caller_id,omitempty"`     // Symbol containing the call; empty for file scope
+	Language     string `json:"language"`                // "php" for add_action() & co, "js" for @wordpress/hooks' addAction()
+	Namespace    string `json:"namespace,omitempty"`     // @wordpress/hooks namespace, e.g. "my-plugin/filter-settings"
+	File         string `json:"file"`
+	Line         int    `json:"line"`
+}
+
+// CallSite records one place a hook is fired.
+type CallSite struct {
+	CallerID string   `json:"caller_id,omitempty"` // Enclosing symbol; empty for file scope
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Function string   `json:"function"`       // do_action, apply_filters, ...
+	Args     []string `json:"args,omitempty"` // Argument expressions after the hook name
+	Doc      DocBlock `json:"-"`              // Docblock preceding this call, if any
+
+	// For "This filter is documented in <file>" comments: the referenced file,
+	// and the line of the documented call site there (populated by resolver).
+	DocumentedIn   string `json:"documented_in,omitempty"`
+	DocumentedLine int    `json:"documented_line,omitempty"`
+}
+
+// CallRef is a call expression found in a function or method body, recorded
+// by the parser and resolved to symbol IDs by the resolver's call-graph pass.
+type CallRef struct {
+	Kind  string `json:"kind"`            // "function", "method", "static" or "new"
+	Class string `json:"class,omitempty"` // Receiver: class name, "self", "static", "parent", "$this" or another variable
+	Name  string `json:"name"`            // Function or method name; empty for "new"
+	Line  int    `json:"line"`
+}
+
+// TraitRule is one conflict-resolution or aliasing rule from a trait `use`
+// block, e.g. `A::hello insteadof B;` or `hello as protected greet;`.
+type TraitRule struct {
+	Trait      string   `json:"trait,omitempty"`      // Trait qualifying the method; empty if unqualified
+	Method     string   `json:"method"`               // Method the rule applies to
+	InsteadOf  []string `json:"insteadof,omitempty"`  // Traits whose same-named method is excluded
+	Alias      string   `json:"alias,omitempty"`      // New name the method is also available under
+	Visibility string   `json:"visibility,omitempty"` // Changed visibility, e.g. "protected"
+}
+
+// TraitMember is a method a class gets from a trait (populated by resolver).
+type TraitMember struct {
+	Trait      string `json:"trait"`                // Trait the method comes from
+	MethodID   string `json:"method_id"`            // The trait's method symbol
+	Name       string `json:"name"`                 // Name on the using class (differs when aliased)
+	Visibility string `json:"visibility,omitempty"` // Visibility changed by an `as` rule, if any
+}
+
+// Member statuses in a class's complete member set.
+const (
+	MemberDeclared   = "declared"   // Declared in the class itself
+	MemberOverridden = "overridden" // Declared in the class, replacing an inherited member
+	MemberInherited  = "inherited"  // Inherited from a parent, interface or trait
+)
+
+// MemberRef is one entry in the complete member set of a class, as seen from
+// that class (populated by resolver).
+type MemberRef struct {
+	ID     string     `json:"id"`
+	Name   string     `json:"name"` // Name on the class (differs from the symbol's for trait aliases)
+	Kind   SymbolKind `json:"kind"`
+	Origin string     `json:"origin"` // Class, interface or trait declaring the member
+	Status string     `json:"status"` // MemberDeclared, MemberOverridden or MemberInherited
+}
+
+// Deprecation records a runtime deprecation notice, e.g.
+// _deprecated_function( __FUNCTION__, '3.0.0', 'wp_get_current_user()' ).
+type Deprecation struct {
+	Function      string `json:"function"`                 // _deprecated_function, apply_filters_deprecated, ...
+	TargetID      string `json:"target_id,omitempty"`      // Deprecated symbol; empty for _deprecated_file
+	TargetFile    string `json:"target_file,omitempty"`    // Deprecated file, for _deprecated_file
+	Version       string `json:"version,omitempty"`        // Version the deprecation happened in
+	Replacement   string `json:"replacement,omitempty"`    // Replacement as written, e.g. "wp_get_current_user()"
+	ReplacementID string `json:"replacement_id,omitempty"` // Resolved replacement symbol (populated by resolver)
+	Message       string `json:"message,omitempty"`        // Extra message, or the argument notice for _deprecated_argument
+	File          string `json:"file"`
+	Line          int    `json:"line"`
+}
+
+// TypeExpr kinds.
+const (
+	TypeNamed        = "named"
+	TypeUnion        = "union"
+	TypeIntersection = "intersection"
+)
+
+// TypeExpr is a structured native PHP type: a named type, or a union or
+// intersection of other types. Nullable types (?T) are normalised to T|null,
+// and DNF types such as (A&B)|null are unions of intersections.
+type TypeExpr struct {
+	Kind  string     `json:"kind"`            // TypeNamed, TypeUnion or TypeIntersection
+	Name  string     `json:"name,omitempty"`  // For named types, as written: "int", "\Foo\Bar", "static"
+	Types []TypeExpr `json:"types,omitempty"` // Members of a union or intersection
+}
+
+// String renders the type in PHP syntax, parenthesising intersections
+// inside unions: "int|null", "(A&B)|null".
+func (t TypeExpr) String() string {
+	switch t.Kind {
+	case TypeUnion, TypeIntersection:
+		sep := "|"
+		if t.Kind == TypeIntersection {
+			sep = "&"
+		}
+		parts := make([]string, len(t.Types))
+		for i, member := range t.Types {
+			parts[i] = member.String()
+			if t.Kind == TypeUnion && member.Kind == TypeIntersection {
+				parts[i] = "(" + parts[i] + ")"
+			}
+		}
+		return strings.Join(parts, sep)
+	default:
+		return t.Name
+	}
+}
+
+// IsNullable reports whether the type admits null, as ?T and T|null do.
+func (t TypeExpr) IsNullable() bool {
+	for _, member := range t.Members() {
+		if member.Kind == TypeNamed && strings.EqualFold(member.Name, "null") {
+			return true
+		}
+	}
+	return false
+}
+
+// Members returns the members of a union, or the type itself otherwise.
+func (t TypeExpr) Members() []TypeExpr {
+	if t.Kind == TypeUnion {
+		return t.Types
+	}
+	return []TypeExpr{t}
+}
+
 // Param represents a function/method parameter.
 type Param struct {
 	Name        string `json:"name"`
-	Type        string `json:"type"`
+	Type        string `json:"type"` // Native type if declared, else the documented type
 	Description string `json:"description"`
 	Default     string `json:"default,omitempty"`
 	IsVariadic  bool   `json:"is_variadic,omitempty"`
 	IsNullable  bool   `json:"is_nullable,omitempty"`
 	IsPassByRef bool   `json:"is_pass_by_ref,omitempty"`
+	IsOptional  bool   `json:"is_optional,omitempty"` // JSDoc [name], or a TS `name?:` prop
+	IsRequired  bool   `json:"is_required,omitempty"` // A prop its TS type or JSDoc tag declares without ? or [name]
+
+	Native  *TypeExpr `json:"native,omitempty"`   // Declared type from the signature
+	DocType string    `json:"doc_type,omitempty"` // Type from the @param tag
+
+	// Keys of an array parameter documented with WordPress hash notation
+	// ({ @type string $key Description. }), nested arbitrarily deep.
+	Fields []Param `json:"fields,omitempty"`
 }
 
 // ReturnValue represents a function/method return.
 type ReturnValue struct {
-	Type        string `json:"type"`
-	Description string `json:"description"`
+	Type        string    `json:"type"` // Documented type if any, else the native type
+	Description string    `json:"description"`
+	Native      *TypeExpr `json:"native,omitempty"` // Declared return type from the signature
 }
 
 // DocBlock represents a parsed documentation comment.
@@ -55,6 +215,54 @@ type DocBlock struct {
 	SeeAlso     []string            `json:"see_also,omitempty"`
 	Links       []string            `json:"links,omitempty"`
 	Access      string              `json:"access,omitempty"` // public, private, protected
+
+	// Structured forms of the tags in Tags
+	Globals  []DocTag      `json:"globals,omitempty"`  // @global: globals the code reads or writes
+	Uses     []string      `json:"uses,omitempty"`     // @uses references
+	Throws   []DocTag      `json:"throws,omitempty"`   // @throws: exception type and when
+	Examples []string      `json:"examples,omitempty"` // @example bodies, line breaks preserved
+	Todos    []string      `json:"todos,omitempty"`    // @todo notes
+	Var      *DocTag       `json:"var,omitempty"`      // @var on a property, constant or variable
+	Types    []DocTag      `json:"types,omitempty"`    // Top-level @type tags (nested ones become Param.Fields)
+	Analysis []AnalysisTag `json:"analysis,omitempty"` // @phpstan-* and @psalm-* annotations
+	Ignore   bool          `json:"ignore,omitempty"`   // @ignore: leave out of generated docs
+	Internal bool          `json:"internal,omitempty"` // @internal: not part of the public API
+
+	// Inline {@see} and {@link} tags in the summary, description, @param
+	// and @return text
+	Inline []InlineTag `json:"inline,omitempty"`
+}
+
+// InlineTag is an inline {@see Target Text} or {@link Target Text} tag.
+// TargetID is set by the resolver when Target names a documented symbol.
+type InlineTag struct {
+	Raw      string `json:"raw"` // The tag as written, including braces
+	Tag      string `json:"tag"` // "see" or "link"
+	Target   string `json:"target"`
+	Text     string `json:"text,omitempty"`
+	TargetID string `json:"target_id,omitempty"`
+}
+
+// IsURL reports whether the tag points at a web page rather than a symbol.
+func (t InlineTag) IsURL() bool {
+	return strings.HasPrefix(t.Target, "http://") || strings.HasPrefix(t.Target, "https://")
+}
+
+// DocTag is a typed docblock tag such as "@global wpdb $wpdb Description."
+// or "@throws Exception When ...". Name is the variable name without "$", for
+// tags that have one.
+type DocTag struct {
+	Type        string `json:"type,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// AnalysisTag is a static analysis annotation, e.g. "@phpstan-return
+// array<string, int>" is {Tool: "phpstan", Tag: "return", Value: "array<string, int>"}.
+type AnalysisTag struct {
+	Tool  string `json:"tool"`
+	Tag   string `json:"tag"`
+	Value string `json:"value"`
 }
 
 // SourceLocation pinpoints where a symbol is defined.
@@ -79,33 +287,129 @@ type Symbol struct {
 	// For functions/methods
 	Params  []Param      `json:"params,omitempty"`
 	Returns *ReturnValue `json:"returns,omitempty"`
+	Calls   []CallRef    `json:"calls,omitempty"` // Raw call expressions in the body
+
+	// For properties/constants/enums
+	Type      string   `json:"type,omitempty"`      // Declared type, the @var type, or an enum's backing type
+	Value     string   `json:"value,omitempty"`     // Default value or constant expression
+	Modifiers []string `json:"modifiers,omitempty"` // As written, e.g. "abstract", "public", "static", "readonly"; also on classes and methods
+
+	// For define() constants guarded by `if ( ! defined( ... ) )`, which
+	// wp-config.php can set first
+	IsOverridable bool `json:"overridable,omitempty"`
+
+	// Class names in this symbol's type hints -> resolved symbol IDs (populated by resolver)
+	TypeRefs map[string]string `json:"type_refs,omitempty"`
+
+	// For React components: props collected from the destructured props
+	// parameter, the TypeScript props type and JSDoc props.* tags
+	Props     []Param `json:"props,omitempty"`
+	PropsType string  `json:"props_type,omitempty"` // Name of the TypeScript props type, if any
 
 	// For classes/interfaces/traits
 	Extends    []string `json:"extends,omitempty"`
 	Implements []string `json:"implements,omitempty"`
-	Members    []string `json:"members,omitempty"`  // IDs of child symbols (methods, properties)
+	Members    []string `json:"members,omitempty"`   // IDs of child symbols (methods, properties)
 	ParentID   string   `json:"parent_id,omitempty"` // For methods: the owning class ID
 
+	// For classes/traits/enums using traits
+	Traits       []string      `json:"traits,omitempty"`        // Traits composed with `use`
+	TraitRules   []TraitRule   `json:"trait_rules,omitempty"`   // insteadof/as rules from `use` blocks
+	TraitMembers []TraitMember `json:"trait_members,omitempty"` // Methods gained from traits (populated by resolver)
+
+	// Complete member set including everything inherited through Extends,
+	// Implements and Traits (populated by resolver)
+	AllMembers []MemberRef `json:"all_members,omitempty"`
+
 	// For hooks
-	HookType  HookType `json:"hook_type,omitempty"`
-	HookTag   string   `json:"hook_tag,omitempty"`   // The hook name/tag string
-	CallSites []string `json:"call_sites,omitempty"` // Where do_action/apply_filters is called
+	HookType   HookType       `json:"hook_type,omitempty"`
+	HookTag    string         `json:"hook_tag,omitempty"`   // The hook name/tag string
+	IsDynamic  bool           `json:"is_dynamic,omitempty"` // Tag contains {$placeholder} parts
+	Expansions []string       `json:"expansions,omitempty"` // Known concrete tags for a dynamic hook (populated by resolver)
+	CallSites  []CallSite     `json:"call_sites,omitempty"` // Every do_action/apply_filters call
+	Callbacks  []HookCallback `json:"callbacks,omitempty"`  // add_action/add_filter registrations (populated by resolver)
+
+	// Runtime deprecation notices (populated by resolver)
+	Deprecations []Deprecation `json:"deprecations,omitempty"`
 
 	// Cross-references (populated by resolver)
-	UsedBy    []string `json:"used_by,omitempty"`   // Symbols that call this
-	Uses      []string `json:"uses,omitempty"`      // Symbols this calls
-	Overrides string   `json:"overrides,omitempty"` // Parent method this overrides
+	UsedBy            []string `json:"used_by,omitempty"`            // Symbols that call this
+	Uses              []string `json:"uses,omitempty"`               // Symbols this calls
+	Overrides         string   `json:"overrides,omitempty"`          // Nearest ancestor method this overrides
+	OverriddenBy      []string `json:"overridden_by,omitempty"`      // Methods that override or implement this one
+	ImplementsMethods []string `json:"implements_methods,omitempty"` // Interface methods this method satisfies
 
 	// Source
 	Location SourceLocation `json:"location"`
 }
 
+// ImportKind distinguishes PHP's three `use` import tables.
+type ImportKind string
+
+const (
+	ImportClass    ImportKind = "class"
+	ImportFunction ImportKind = "function"
+	ImportConst    ImportKind = "const"
+)
+
+// PHPImports is the `use` import table of one namespace in a PHP file.
+// Class and function aliases are keyed in lower case, as PHP matches them
+// case-insensitively; constant aliases are case-sensitive.
+type PHPImports struct {
+	Classes   map[string]string `json:"classes,omitempty"`
+	Functions map[string]string `json:"functions,omitempty"`
+	Constants map[string]string `json:"constants,omitempty"`
+}
+
+// importScope identifies one namespace block of a file.
+type importScope struct {
+	file, namespace string
+}
+
+// Visibility returns a symbol's visibility: its visibility modifier, else a
+// legacy @access tag, else "public" for class members (PHP's default) and ""
+// for everything else.
+func (s *Symbol) Visibility() string {
+	for _, m := range s.Modifiers {
+		switch m {
+		case "public", "protected", "private":
+			return m
+		}
+	}
+	if s.Doc.Access != "" {
+		return s.Doc.Access
+	}
+	if s.ParentID != "" {
+		return "public"
+	}
+	return ""
+}
+
 // Registry is the central store for all extracted symbols.
+//
+// An ID can be declared more than once: pluggable functions, function_exists()
+// polyfills in compat.php, classes guarded by class_exists(). The registry
+// keeps every declaration as a variant and picks a primary one, which is what
+// Get, ByKind and All return; see Variants and Conflicts.
 type Registry struct {
-	mu      sync.RWMutex
-	symbols map[string]*Symbol
-	byKind  map[SymbolKind][]*Symbol
-	byFile  map[string][]*Symbol
+	mu       sync.RWMutex
+	symbols  map[string]*Symbol       // Primary declaration of each ID
+	byKind   map[SymbolKind][]*Symbol // Primary declarations by kind
+	byFile   map[string][]*Symbol     // Every declaration in each file, including non-primary variants
+	variants map[string][]*Symbol     // All declarations of IDs declared more than once
+
+	// Hook registrations are collected separately because the hook symbol
+	// may not have been parsed yet when its add_action call is seen.
+	hookCallbacks []HookCallback
+
+	// _deprecated_*() notices, collected for the same reason.
+	deprecations []Deprecation
+
+	// Names passed to register_post_type() etc., keyed by category.
+	registeredNames map[string]map[string]bool
+
+	// PHP `use` imports per file and namespace.
+	imports map[importScope]*PHPImports
 }
 
 func NewRegistry() *Registry {
@@ -113,15 +417,257 @@ func NewRegistry() *Registry {
 		symbols: make(map[string]*Symbol),
 		byKind:  make(map[SymbolKind][]*Symbol),
 		byFile:  make(map[string][]*Symbol),
+
+		variants:        make(map[string][]*Symbol),
+		registeredNames: make(map[string]map[string]bool),
+		imports:         make(map[importScope]*PHPImports),
 	}
 }
 
+// Add registers a symbol. If its ID is already taken, both are kept as
+// variants and the preferred one becomes (or stays) the primary.
 func (r *Registry) Add(s *Symbol) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
+	r.indexFile(s)
+
+	existing, ok := r.symbols[s.ID]
+	if !ok {
+		r.symbols[s.ID] = s
+		r.byKind[s.Kind] = append(r.byKind[s.Kind], s)
+		return
+	}
+	if len(r.variants[s.ID]) == 0 {
+		r.variants[s.ID] = []*Symbol{existing}
+	}
+	r.variants[s.ID] = append(r.variants[s.ID], s)
+	if r.preferVariant(s, existing) {
+		r.setPrimary(existing, s)
+	}
+}
+
+// setPrimary makes s the primary declaration in place of old. The members of
+// a class follow it, so that a class and its methods always come from the
+// same declaration.
+func (r *Registry) setPrimary(old, s *Symbol) {
+	r.symbols[s.ID] = s
+	r.removeByKind(old)
+	r.byKind[s.Kind] = append(r.byKind[s.Kind], s)
+
+	for _, id := range s.Members {
+		current := r.symbols[id]
+		for _, v := range r.variants[id] {
+			if v != current && r.preferVariant(v, current) {
+				current = v
+			}
+		}
+		if current != r.symbols[id] {
+			r.setPrimary(r.symbols[id], current)
+		}
+	}
+}
+
+// preferVariant reports whether a should be the primary declaration over b.
+// Members prefer the declaration inside their class's primary declaration;
+// otherwise documented declarations win, then the earliest file and line, so
+// the choice doesn't depend on the order files were parsed in.
+func (r *Registry) preferVariant(a, b *Symbol) bool {
+	if parent := r.symbols[a.ParentID]; parent != nil && a.ParentID != "" {
+		aIn, bIn := declaredIn(a, parent), declaredIn(b, parent)
+		if aIn != bIn {
+			return aIn
+		}
+	}
+	return preferDocumented(a, b)
+}
+
+// declaredIn reports whether member lies within the declaration of parent.
+func declaredIn(member, parent *Symbol) bool {
+	return member.Location.File == parent.Location.File &&
+		member.Location.StartLine >= parent.Location.StartLine &&
+		member.Location.StartLine <= parent.Location.EndLine
+}
+
+func preferDocumented(a, b *Symbol) bool {
+	aDoc, bDoc := a.Doc.Summary != "", b.Doc.Summary != ""
+	if aDoc != bDoc {
+		return aDoc
+	}
+	if a.Location.File != b.Location.File {
+		return a.Location.File < b.Location.File
+	}
+	return a.Location.StartLine < b.Location.StartLine
+}
+
+func (r *Registry) removeByKind(s *Symbol) {
+	list := r.byKind[s.Kind]
+	for i, other := range list {
+		if other == s {
+			r.byKind[s.Kind] = append(list[:i:i], list[i+1:]...)
+			return
+		}
+	}
+}
+
+// Variants returns every declaration of an ID, sorted by file and line, or
+// nil if the ID was declared only once.
+func (r *Registry) Variants(id string) []*Symbol {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.variants[id]) == 0 {
+		return nil
+	}
+	result := make([]*Symbol, len(r.variants[id]))
+	copy(result, r.variants[id])
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Location.File != result[j].Location.File {
+			return result[i].Location.File < result[j].Location.File
+		}
+		return result[i].Location.StartLine < result[j].Location.StartLine
+	})
+	return result
+}
+
+// Conflicts returns the sorted IDs that were declared more than once.
+func (r *Registry) Conflicts() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]string, 0, len(r.variants))
+	for id := range r.variants {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// AddHookCallback records an add_action/add_filter style registration.
+func (r *Registry) AddHookCallback(cb HookCallback) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hookCallbacks = append(r.hookCallbacks, cb)
+}
+
+// HookCallbacks returns all recorded hook registrations.
+func (r *Registry) HookCallbacks() []HookCallback {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]HookCallback, len(r.hookCallbacks))
+	copy(result, r.hookCallbacks)
+	return result
+}
+
+// AddDeprecation records a _deprecated_*() style notice.
+func (r *Registry) AddDeprecation(d Deprecation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deprecations = append(r.deprecations, d)
+}
+
+// Deprecations returns all recorded deprecation notices.
+func (r *Registry) Deprecations() []Deprecation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]Deprecation, len(r.deprecations))
+	copy(result, r.deprecations)
+	return result
+}
+
+// AddOrMerge adds s, or if a symbol with the same ID already exists, calls
+// merge with the existing symbol while holding the registry lock. This lets
+// parser workers accumulate data on shared symbols (e.g. hook call sites)
+// without racing each other. merge may move the symbol to another file; the
+// file index follows it.
+func (r *Registry) AddOrMerge(s *Symbol, merge func(existing *Symbol)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.symbols[s.ID]; ok {
+		file := existing.Location.File
+		merge(existing)
+		if existing.Location.File != file {
+			r.unindexFile(file, existing)
+			r.indexFile(existing)
+		}
+		return
+	}
 	r.symbols[s.ID] = s
 	r.byKind[s.Kind] = append(r.byKind[s.Kind], s)
-	r.byFile[s.Location.File] = append(r.byFile[s.Location.File], s)
+	r.indexFile(s)
+}
+
+// indexFile lists s under its file. Symbols without a location, such as
+// globals only mentioned in @global tags, belong to no file.
+func (r *Registry) indexFile(s *Symbol) {
+	if s.Location.File != "" {
+		r.byFile[s.Location.File] = append(r.byFile[s.Location.File], s)
+	}
+}
+
+func (r *Registry) unindexFile(file string, s *Symbol) {
+	list := r.byFile[file]
+	for i, other := range list {
+		if other == s {
+			r.byFile[file] = append(list[:i:i], list[i+1:]...)
+			break
+		}
+	}
+	if len(r.byFile[file]) == 0 {
+		delete(r.byFile, file)
+	}
+}
+
+// AddRegisteredName records a post type, post status or taxonomy name found
+// in a register_*() call.
+func (r *Registry) AddRegisteredName(category, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.registeredNames[category] == nil {
+		r.registeredNames[category] = make(map[string]bool)
+	}
+	r.registeredNames[category][name] = true
+}
+
+// RegisteredNames returns the sorted names recorded for a category.
+func (r *Registry) RegisteredNames(category string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]string, 0, len(r.registeredNames[category]))
+	for name := range r.registeredNames[category] {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// AddImport records a PHP `use` import of name under alias.
+func (r *Registry) AddImport(file, namespace string, kind ImportKind, alias, name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	scope := importScope{file, namespace}
+	imports := r.imports[scope]
+	if imports == nil {
+		imports = &PHPImports{
+			Classes:   make(map[string]string),
+			Functions: make(map[string]string),
+			Constants: make(map[string]string),
+		}
+		r.imports[scope] = imports
+	}
+	switch kind {
+	case ImportClass:
+		imports.Classes[strings.ToLower(alias)] = name
+	case ImportFunction:
+		imports.Functions[strings.ToLower(alias)] = name
+	case ImportConst:
+		imports.Constants[alias] = name
+	}
+}
+
+// Imports returns the import table for a namespace in a file, or nil if it
+// has no `use` imports.
+func (r *Registry) Imports(file, namespace string) *PHPImports {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.imports[importScope{file, namespace}]
 }
 
 func (r *Registry) Get(id string) *Symbol {
@@ -142,6 +688,18 @@ func (r *Registry) ByFile(path string) []*Symbol {
 	return r.byFile[path]
 }
 
+// Files returns the sorted paths of all files that define symbols.
+func (r *Registry) Files() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]string, 0, len(r.byFile))
+	for path := range r.byFile {
+		result = append(result, path)
+	}
+	sort.Strings(result)
+	return result
+}
+
 func (r *Registry) All() []*Symbol {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -152,6 +710,25 @@ func (r *Registry) All() []*Symbol {
 	return result
 }
 
+// Declarations returns every declaration: the primary symbols All returns,
+// followed by the non-primary variants of IDs declared more than once.
+func (r *Registry) Declarations() []*Symbol {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]*Symbol, 0, len(r.symbols))
+	for _, s := range r.symbols {
+		result = append(result, s)
+	}
+	for id, variants := range r.variants {
+		for _, v := range variants {
+			if v != r.symbols[id] {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
+
 func (r *Registry) Count() int {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
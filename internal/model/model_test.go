@@ -0,0 +1,172 @@
+package model
+
+import (
+	"fmt"
+	"testing"
+)
+
+func decl(id, file string, line int, summary string) *Symbol {
+	return &Symbol{
+		ID:       id,
+		Name:     id,
+		Kind:     KindFunction,
+		Language: "php",
+		Doc:      DocBlock{Summary: summary},
+		Location: SourceLocation{File: file, StartLine: line, EndLine: line + 5},
+	}
+}
+
+// where renders a declaration's location, or "-" for nil.
+func where(s *Symbol) string {
+	if s == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%s:%d", s.Location.File, s.Location.StartLine)
+}
+
+func TestRegistryVariants(t *testing.T) {
+	tests := []struct {
+		name    string
+		decls   []*Symbol
+		primary string
+	}{
+		{
+			name:    "single declaration",
+			decls:   []*Symbol{decl("f", "a.php", 1, "")},
+			primary: "a.php:1",
+		},
+		{
+			name:    "documented declaration wins",
+			decls:   []*Symbol{decl("f", "a.php", 1, ""), decl("f", "b.php", 1, "Docs.")},
+			primary: "b.php:1",
+		},
+		{
+			name:    "documented declaration wins in either order",
+			decls:   []*Symbol{decl("f", "b.php", 1, "Docs."), decl("f", "a.php", 1, "")},
+			primary: "b.php:1",
+		},
+		{
+			name:    "earliest file, then line, breaks ties",
+			decls:   []*Symbol{decl("f", "b.php", 1, ""), decl("f", "a.php", 9, ""), decl("f", "a.php", 3, "")},
+			primary: "a.php:3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := NewRegistry()
+			for _, d := range tt.decls {
+				reg.Add(d)
+			}
+
+			if got := where(reg.Get("f")); got != tt.primary {
+				t.Errorf("primary = %s, want %s", got, tt.primary)
+			}
+			if byKind := reg.ByKind(KindFunction); len(byKind) != 1 || byKind[0] != reg.Get("f") {
+				t.Errorf("ByKind lists %d declarations, want the primary only", len(byKind))
+			}
+			if got := len(reg.Declarations()); got != len(tt.decls) {
+				t.Errorf("Declarations() has %d entries, want %d", got, len(tt.decls))
+			}
+
+			variants := reg.Variants("f")
+			conflicts := reg.Conflicts()
+			if len(tt.decls) == 1 {
+				if variants != nil || len(conflicts) != 0 {
+					t.Errorf("single declaration reported as a conflict")
+				}
+				return
+			}
+			if len(variants) != len(tt.decls) || len(conflicts) != 1 || conflicts[0] != "f" {
+				t.Errorf("got %d variants and conflicts %v, want %d variants of f", len(variants), conflicts, len(tt.decls))
+			}
+			for i := 1; i < len(variants); i++ {
+				if where(variants[i-1]) > where(variants[i]) {
+					t.Errorf("variants not sorted by location: %s before %s", where(variants[i-1]), where(variants[i]))
+				}
+			}
+		})
+	}
+}
+
+func TestRegistryMembersFollowClass(t *testing.T) {
+	class := func(file, summary string) *Symbol {
+		c := decl("C", file, 1, summary)
+		c.Kind = KindClass
+		c.Location.EndLine = 20
+		c.Members = []string{"C::m"}
+		return c
+	}
+	method := func(file, summary string) *Symbol {
+		m := decl("C::m", file, 5, summary)
+		m.Kind = KindMethod
+		m.ParentID = "C"
+		return m
+	}
+
+	// The class in b.php is documented and becomes primary; the method
+	// follows it even though only a.php documents the method.
+	orders := map[string][]*Symbol{
+		"a then b": {class("a.php", ""), method("a.php", "Docs."), class("b.php", "Docs."), method("b.php", "")},
+		"b then a": {class("b.php", "Docs."), method("b.php", ""), class("a.php", ""), method("a.php", "Docs.")},
+	}
+	for name, decls := range orders {
+		t.Run(name, func(t *testing.T) {
+			reg := NewRegistry()
+			for _, d := range decls {
+				reg.Add(d)
+			}
+			if got := where(reg.Get("C")); got != "b.php:1" {
+				t.Errorf("class primary = %s, want b.php:1", got)
+			}
+			if got := where(reg.Get("C::m")); got != "b.php:5" {
+				t.Errorf("method primary = %s, want b.php:5", got)
+			}
+			if methods := reg.ByKind(KindMethod); len(methods) != 1 || methods[0] != reg.Get("C::m") {
+				t.Errorf("ByKind(method) doesn't list the primary only")
+			}
+		})
+	}
+}
+
+func TestRegistryAddOrMergeReindexesFile(t *testing.T) {
+	reg := NewRegistry()
+	global := &Symbol{ID: "global:wpdb", Kind: KindGlobal, Language: "php"}
+	reg.AddOrMerge(global, nil)
+	if files := reg.Files(); len(files) != 0 {
+		t.Fatalf("mention without a location indexed under %v", files)
+	}
+
+	reg.AddOrMerge(&Symbol{ID: "global:wpdb"}, func(existing *Symbol) {
+		existing.Location = SourceLocation{File: "wp-settings.php", StartLine: 3}
+	})
+	if got := reg.ByFile("wp-settings.php"); len(got) != 1 || got[0] != global {
+		t.Errorf("ByFile(wp-settings.php) = %v, want the merged global", got)
+	}
+}
+
+func TestTypeExpr(t *testing.T) {
+	named := func(name string) TypeExpr { return TypeExpr{Kind: TypeNamed, Name: name} }
+	tests := []struct {
+		typ      TypeExpr
+		str      string
+		nullable bool
+	}{
+		{named("int"), "int", false},
+		{named("null"), "null", true},
+		{TypeExpr{Kind: TypeUnion, Types: []TypeExpr{named("WP_Post"), named("NULL")}}, "WP_Post|NULL", true},
+		{TypeExpr{Kind: TypeIntersection, Types: []TypeExpr{named("A"), named("B")}}, "A&B", false},
+		{TypeExpr{Kind: TypeUnion, Types: []TypeExpr{
+			{Kind: TypeIntersection, Types: []TypeExpr{named("A"), named("B")}},
+			named("null"),
+		}}, "(A&B)|null", true},
+	}
+	for _, tt := range tests {
+		if got := tt.typ.String(); got != tt.str {
+			t.Errorf("String() = %q, want %q", got, tt.str)
+		}
+		if got := tt.typ.IsNullable(); got != tt.nullable {
+			t.Errorf("%s: IsNullable() = %v, want %v", tt.str, got, tt.nullable)
+		}
+	}
+}
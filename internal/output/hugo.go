@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 	"text/template"
@@ -22,6 +23,9 @@ type Hugo struct {
 	version      string // normalized major.minor e.g. "6.7"
 	guidesDir    string // optional path to hand-written guide markdown files
 	overridesDir string // optional path to override markdown files
+	hideInternal bool   // leave out symbols marked @ignore or @internal
+
+	hidden map[string]bool // IDs of symbols left out of the output
 }
 
 // NewHugo creates a Hugo site generator that writes to outDir.
@@ -36,6 +40,12 @@ func NewHugo(outDir, srcRoot, wpVersion, guidesDir, overridesDir string) *Hugo {
 	}
 }
 
+// SetHideInternal leaves symbols documented as @ignore or @internal, and
+// their members, out of the generated site.
+func (h *Hugo) SetHideInternal(hide bool) {
+	h.hideInternal = hide
+}
+
 // normalizeVersion extracts major.minor from a full version string like "6.7.1".
 func normalizeVersion(v string) string {
 	parts := strings.SplitN(v, ".", 3)
@@ -72,14 +82,18 @@ func (h *Hugo) Generate(reg *model.Registry) error {
 
 	// Write layouts
 	layoutFiles := map[string]string{
-		filepath.Join("layouts", "_default", "baseof.html"):  layoutBaseof,
-		filepath.Join("layouts", "_default", "list.html"):    layoutList,
-		filepath.Join("layouts", "_default", "single.html"):  layoutSingle,
-		filepath.Join("layouts", "index.html"):               layoutIndex,
-		filepath.Join("layouts", "guides", "list.html"):      layoutGuideList,
-		filepath.Join("layouts", "guides", "single.html"):    layoutGuideSingle,
-		filepath.Join("layouts", "partials", "nav.html"):     partialNav,
-		filepath.Join("layouts", "partials", "meta.html"):    partialMeta,
+		filepath.Join("layouts", "_default", "baseof.html"):       layoutBaseof,
+		filepath.Join("layouts", "_default", "list.html"):         layoutList,
+		filepath.Join("layouts", "_default", "single.html"):       layoutSingle,
+		filepath.Join("layouts", "index.html"):                    layoutIndex,
+		filepath.Join("layouts", "guides", "list.html"):           layoutGuideList,
+		filepath.Join("layouts", "guides", "single.html"):         layoutGuideSingle,
+		filepath.Join("layouts", "partials", "nav.html"):          partialNav,
+		filepath.Join("layouts", "partials", "meta.html"):         partialMeta,
+		filepath.Join("layouts", "partials", "param-fields.html"): partialParamFields,
+		filepath.Join("layouts", "_default", "duplicates.html"):   layoutDuplicates,
+		filepath.Join("layouts", "partials", "ref.html"):          partialRef,
+		filepath.Join("layouts", "partials", "type.html"):         partialType,
 	}
 	for path, content := range layoutFiles {
 		if err := h.writeFile(path, content); err != nil {
@@ -106,24 +120,16 @@ func (h *Hugo) Generate(reg *model.Registry) error {
 		return fmt.Errorf("writing version index: %w", err)
 	}
 
-	// Generate content by kind (under versioned path)
-	kindSections := []struct {
-		kind    model.SymbolKind
-		section string
-		title   string
-	}{
-		{model.KindFunction, "functions", "Functions"},
-		{model.KindClass, "classes", "Classes"},
-		{model.KindMethod, "methods", "Methods"},
-		{model.KindHook, "hooks", "Hooks"},
-		{model.KindInterface, "interfaces", "Interfaces"},
-		{model.KindTrait, "traits", "Traits"},
-		{model.KindEnum, "enums", "Enums"},
-		{model.KindComponent, "components", "Components"},
-	}
+	h.hidden = h.hiddenSymbols(reg)
 
+	// Generate content by kind (under versioned path)
 	for _, ks := range kindSections {
-		symbols := reg.ByKind(ks.kind)
+		var symbols []*model.Symbol
+		for _, sym := range reg.ByKind(ks.kind) {
+			if h.hasOwnPage(sym) && sectionOf(sym) == ks.section {
+				symbols = append(symbols, sym)
+			}
+		}
 		if len(symbols) == 0 {
 			continue
 		}
@@ -146,14 +152,25 @@ func (h *Hugo) Generate(reg *model.Registry) error {
 			return sorted[i].Name < sorted[j].Name
 		})
 
-		// Individual symbol pages
+		// Individual symbol pages, plus one per extra declaration of an ID
+		// that is declared more than once
 		for _, sym := range sorted {
-			if err := h.writeSymbolPage(ks.section, sym); err != nil {
-				return fmt.Errorf("writing symbol %s: %w", sym.ID, err)
+			variants := h.variantPages(reg, sym)
+			if len(variants) == 0 {
+				variants = []variantData{{Symbol: sym, Slug: symbolSlug(sym.ID)}}
+			}
+			for _, v := range variants {
+				if err := h.writeSymbolPage(reg, ks.section, v.Slug, v.Symbol, variants); err != nil {
+					return fmt.Errorf("writing symbol %s: %w", sym.ID, err)
+				}
 			}
 		}
 	}
 
+	if err := h.writeDuplicatesPage(reg); err != nil {
+		return fmt.Errorf("writing duplicates page: %w", err)
+	}
+
 	// Write guides (if guides directory provided)
 	if err := h.writeGuides(); err != nil {
 		return fmt.Errorf("writing guides: %w", err)
@@ -165,13 +182,173 @@ func (h *Hugo) Generate(reg *model.Registry) error {
 	return nil
 }
 
+// kindSections lists the content section each symbol kind is written to.
+var kindSections = []struct {
+	kind    model.SymbolKind
+	section string
+	title   string
+}{
+	{model.KindFunction, "functions", "Functions"},
+	{model.KindClass, "classes", "Classes"},
+	{model.KindMethod, "methods", "Methods"},
+	{model.KindHook, "hooks", "Hooks"},
+	{model.KindHook, jsHooksSection, "JavaScript Hooks"},
+	{model.KindInterface, "interfaces", "Interfaces"},
+	{model.KindTrait, "traits", "Traits"},
+	{model.KindEnum, "enums", "Enums"},
+	{model.KindTypeAlias, "types", "Types"},
+	{model.KindComponent, "components", "Components"},
+	{model.KindConstant, "constants", "Constants"},
+	{model.KindGlobal, "globals", "Globals"},
+}
+
+// pagePath returns the Hugo page path of a symbol's own page (for use with
+// site.GetPage), or "" if symbols of its kind don't get pages.
+func (h *Hugo) pagePath(sym *model.Symbol) string {
+	if ref, ok := h.pageRef(sym); ok {
+		return "/" + h.version + "/" + ref.Section + "/" + ref.Slug
+	}
+	return ""
+}
+
+// pageRef returns the section and slug of a symbol's own page, if it has one.
+func (h *Hugo) pageRef(sym *model.Symbol) (pageRef, bool) {
+	if !h.hasOwnPage(sym) {
+		return pageRef{}, false
+	}
+	if section := sectionOf(sym); section != "" {
+		return pageRef{Section: section, Slug: symbolSlug(sym.ID)}, true
+	}
+	return pageRef{}, false
+}
+
+// jsHooksSection holds the hooks of @wordpress/hooks, apart from PHP's.
+const jsHooksSection = "js-hooks"
+
+// sectionOf returns the content section a symbol is written to, or "" if
+// its kind has none.
+func sectionOf(sym *model.Symbol) string {
+	if sym.Kind == model.KindHook && sym.Language == "js" {
+		return jsHooksSection
+	}
+	for _, ks := range kindSections {
+		if ks.kind == sym.Kind {
+			return ks.section
+		}
+	}
+	return ""
+}
+
+// variantData is one declaration of an ID that is declared more than once.
+// The primary declaration's page keeps the ID's slug; the others get
+// numbered slugs.
+type variantData struct {
+	*model.Symbol
+	Slug      string
+	Page      string
+	Primary   bool
+	GitHubURL string
+}
+
+// variantPages returns the pages for every declaration of sym's ID, in
+// source order, or nil if the ID was declared only once.
+func (h *Hugo) variantPages(reg *model.Registry, sym *model.Symbol) []variantData {
+	all := reg.Variants(sym.ID)
+	if len(all) == 0 {
+		return nil
+	}
+	base := h.pagePath(sym)
+	slug := symbolSlug(sym.ID)
+	var variants []variantData
+	n := 1
+	for _, v := range all {
+		vd := variantData{
+			Symbol:    v,
+			Slug:      slug,
+			Page:      base,
+			Primary:   v == sym,
+			GitHubURL: h.buildGitHubURL(v.Location.File, v.Location.StartLine, v.Location.EndLine),
+		}
+		if !vd.Primary {
+			n++
+			vd.Slug = fmt.Sprintf("%s-%d", slug, n)
+			vd.Page = fmt.Sprintf("%s-%d", base, n)
+		}
+		variants = append(variants, vd)
+	}
+	return variants
+}
+
+// writeDuplicatesPage writes a disambiguation page listing every ID that is
+// declared more than once, with links to each declaration's page.
+func (h *Hugo) writeDuplicatesPage(reg *model.Registry) error {
+	var b strings.Builder
+	b.WriteString("---\ntitle: \"Duplicate Symbols\"\nlayout: duplicates\n")
+	count := 0
+	for _, id := range reg.Conflicts() {
+		sym := reg.Get(id)
+		if sym == nil || h.pagePath(sym) == "" {
+			continue
+		}
+		if count == 0 {
+			b.WriteString("duplicates:\n")
+		}
+		count++
+		fmt.Fprintf(&b, "  - id: %s\n    kind: %s\n    declarations:\n", yamlEscape(id), yamlEscape(string(sym.Kind)))
+		for _, v := range h.variantPages(reg, sym) {
+			fmt.Fprintf(&b, "      - file: %s\n        line: %d\n        page: %s\n        primary: %t\n",
+				yamlEscape(v.Location.File), v.Location.StartLine, yamlEscape(v.Page), v.Primary)
+		}
+	}
+	if count == 0 {
+		return nil
+	}
+	b.WriteString("---\n")
+	return h.writeFile(filepath.Join("content", h.version, "duplicates.md"), b.String())
+}
+
+// pageRef locates a symbol page within the version: content/<version>/<section>/<slug>.md.
+type pageRef struct {
+	Section string `json:"section"`
+	Slug    string `json:"slug"`
+}
+
+// hasOwnPage reports whether a symbol of a listed kind gets its own page.
+// Class constants share the constant kind but are shown on their class page.
+func (h *Hugo) hasOwnPage(sym *model.Symbol) bool {
+	if h.hidden[sym.ID] {
+		return false
+	}
+	return sym.Kind != model.KindConstant || sym.ParentID == ""
+}
+
+// hiddenSymbols returns the IDs of @ignore and @internal symbols and their
+// members, if they are to be left out.
+func (h *Hugo) hiddenSymbols(reg *model.Registry) map[string]bool {
+	hidden := make(map[string]bool)
+	if !h.hideInternal {
+		return hidden
+	}
+	all := reg.All()
+	for _, sym := range all {
+		if sym.Doc.Ignore || sym.Doc.Internal {
+			hidden[sym.ID] = true
+		}
+	}
+	for _, sym := range all {
+		if hidden[sym.ParentID] {
+			hidden[sym.ID] = true
+		}
+	}
+	return hidden
+}
+
 func (h *Hugo) writeFile(relPath, content string) error {
 	absPath := filepath.Join(h.outDir, relPath)
 	return os.WriteFile(absPath, []byte(content), 0o644)
 }
 
-func (h *Hugo) writeSymbolPage(section string, sym *model.Symbol) error {
-	slug := symbolSlug(sym.ID)
+func (h *Hugo) writeSymbolPage(reg *model.Registry, section, slug string, sym *model.Symbol, variants []variantData) error {
 	relPath := filepath.Join("content", h.version, section, slug+".md")
 	absPath := filepath.Join(h.outDir, relPath)
 
@@ -182,13 +359,51 @@ func (h *Hugo) writeSymbolPage(section string, sym *model.Symbol) error {
 	defer f.Close()
 
 	data := symbolPageData{
-		Symbol:      sym,
-		Signature:   buildSignature(sym),
-		Changelog:   parseChangelog(sym),
-		SourceCode:  h.readSourceContext(sym.Location.File, sym.Location.StartLine),
-		GitHubURL:   h.buildGitHubURL(sym.Location.File, sym.Location.StartLine, sym.Location.EndLine),
-		TracURL:     h.buildTracURL(sym.Location.File, sym.Location.StartLine),
+		Symbol:          sym,
+		Signature:       buildSignature(sym),
+		Changelog:       parseChangelog(sym),
 		OverrideContent: h.readOverride(section, slug),
+		Body:            h.inlineMarkdown(sym.Doc.Description, sym.Doc.Inline, reg),
+		linkInline: func(text string) string {
+			return h.inlineMarkdown(text, sym.Doc.Inline, reg)
+		},
+	}
+	if file := sym.Location.File; file != "" {
+		// Globals only mentioned in @global tags have no source
+		data.SourceCode = h.readSourceContext(file, sym.Location.StartLine)
+		data.GitHubURL = h.buildGitHubURL(file, sym.Location.StartLine, sym.Location.EndLine)
+		data.TracURL = h.buildTracURL(file, sym.Location.StartLine)
+	}
+	data.Slug = slug
+	if len(variants) > 1 {
+		data.Variants = variants
+	}
+	data.groupMembers(reg, h.hidden)
+	data.groupUses(reg)
+	data.collectRefs(h, reg)
+	for _, site := range sym.CallSites {
+		sd := hookSiteData{
+			CallSite:  site,
+			GitHubURL: h.buildGitHubURL(site.File, site.Line, site.Line),
+		}
+		if site.DocumentedLine > 0 {
+			sd.DocumentedURL = h.buildGitHubURL(site.DocumentedIn, site.DocumentedLine, site.DocumentedLine)
+		}
+		data.HookSites = append(data.HookSites, sd)
+	}
+	for _, g := range sym.Doc.Globals {
+		gd := globalRefData{DocTag: g}
+		if global := reg.Get("global:" + g.Name); global != nil {
+			gd.Page = h.pagePath(global)
+		}
+		data.GlobalRefs = append(data.GlobalRefs, gd)
+	}
+	for _, d := range sym.Deprecations {
+		dd := deprecationData{Deprecation: d}
+		if target := reg.Get(d.ReplacementID); target != nil {
+			dd.ReplacementPage = h.pagePath(target)
+		}
+		data.DeprecationNotices = append(data.DeprecationNotices, dd)
 	}
 
 	tmpl := template.Must(template.New("symbol").Funcs(template.FuncMap{
@@ -196,6 +411,7 @@ func (h *Hugo) writeSymbolPage(section string, sym *model.Symbol) error {
 		"yamlMultiline": yamlMultiline,
 		"join":          strings.Join,
 		"safeContent":   safeContent,
+		"toJSON":        toJSON,
 	}).Parse(symbolContentTemplate))
 
 	return tmpl.Execute(f, data)
@@ -450,6 +666,16 @@ func yamlEscape(s string) string {
 	return `"` + s + `"`
 }
 
+// toJSON renders v as compact JSON. JSON is valid YAML flow syntax, which lets
+// arbitrarily nested structures be embedded in front matter on a single line.
+func toJSON(v any) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
 // changelogEntry represents one row in the Changelog table.
 type changelogEntry struct {
 	Version     string
@@ -465,6 +691,352 @@ type symbolPageData struct {
 	GitHubURL       string
 	TracURL         string
 	OverrideContent string
+	Methods         []string          // Member IDs other than properties/constants
+	Properties      []*model.Symbol   // Property members, in declaration order
+	Constants       []*model.Symbol   // Class constant members, in declaration order
+	Cases           []*model.Symbol   // Enum case members, in declaration order
+	HookSites       []hookSiteData    // Every place a hook is fired
+	TraitGroups     []traitGroup      // Methods gained from traits, grouped by trait
+	InheritedGroups []memberGroup     // Inherited members, grouped by declaring class
+	Overridden      []string          // Own member IDs that override an inherited member
+	MemberAccess    map[string]string // Method ID -> visibility, for non-public methods
+
+	DeprecationNotices []deprecationData   // Runtime _deprecated_*() notices
+	GlobalRefs         []globalRefData     // @global tags with the global's page
+	UsesGroups         usesData            // Uses, split by kind
+	UsedByRefs         []relatedRef        // UsedBy, with each user's kind
+	Variants           []variantData       // Every declaration, if the ID is declared more than once
+	Slug               string              // This page's slug
+	Body               string              // Description with inline tags turned into Markdown links
+	linkInline         func(string) string // See Linked
+
+	// Refs maps every symbol ID referenced on the page that has a page of
+	// its own to that page, keyed by lower-cased ID as Hugo lower-cases
+	// front matter keys. ParamTypes and ReturnType split type expressions
+	// into linkable parts.
+	Refs       map[string]pageRef
+	ParamTypes [][]typePart
+	ReturnType []typePart
+	ThrowTypes [][]typePart
+}
+
+// usesData splits what a symbol uses by kind: the functions and methods it
+// calls, the hooks it fires or binds to, and the globals it uses.
+type usesData struct {
+	Calls   []relatedRef
+	Hooks   []relatedRef
+	Globals []relatedRef
+}
+
+// relatedRef is an entry in the Uses or Used By lists of a page.
+type relatedRef struct {
+	ID    string
+	Label string
+	Kind  string
+}
+
+// groupUses splits the symbol's Uses by kind, labelling hooks by their tag and
+// globals by their variable, and records the kind of each symbol in UsedBy.
+func (d *symbolPageData) groupUses(reg *model.Registry) {
+	for _, id := range d.Symbol.Uses {
+		switch {
+		case strings.HasPrefix(id, "hook:"), strings.HasPrefix(id, "jshook:"):
+			_, tag, _ := strings.Cut(id, ":")
+			d.UsesGroups.Hooks = append(d.UsesGroups.Hooks, relatedRef{ID: id, Label: tag})
+		case strings.HasPrefix(id, "global:"):
+			d.UsesGroups.Globals = append(d.UsesGroups.Globals, relatedRef{ID: id, Label: "$" + strings.TrimPrefix(id, "global:")})
+		default:
+			d.UsesGroups.Calls = append(d.UsesGroups.Calls, relatedRef{ID: id, Label: id})
+		}
+	}
+	for _, id := range d.Symbol.UsedBy {
+		ref := relatedRef{ID: id, Label: id}
+		if user := reg.Get(id); user != nil {
+			ref.Kind = string(user.Kind)
+		}
+		d.UsedByRefs = append(d.UsedByRefs, ref)
+	}
+}
+
+// typePart is a piece of a type expression such as "WP_Post|false". Parts
+// naming a class carry its ID so the layout can link them.
+type typePart struct {
+	Text string `json:"text"`
+	ID   string `json:"id,omitempty"`
+}
+
+// typeNameRegex matches the class-like names in a type expression.
+var typeNameRegex = regexp.MustCompile(`\\?[A-Za-z_][A-Za-z0-9_\\]*`)
+
+// typeParts splits a type expression into text and the class names that
+// typeRefs resolves, e.g. "WP_Post|false" into "WP_Post" (linked) and "|false".
+func typeParts(t string, typeRefs map[string]string) []typePart {
+	var parts []typePart
+	last := 0
+	for _, loc := range typeNameRegex.FindAllStringIndex(t, -1) {
+		id, ok := typeRefs[t[loc[0]:loc[1]]]
+		if !ok {
+			continue
+		}
+		if loc[0] > last {
+			parts = append(parts, typePart{Text: t[last:loc[0]]})
+		}
+		parts = append(parts, typePart{Text: t[loc[0]:loc[1]], ID: id})
+		last = loc[1]
+	}
+	if last < len(t) {
+		parts = append(parts, typePart{Text: t[last:]})
+	}
+	return parts
+}
+
+// addRefs records the pages of the given symbol IDs in d.Refs.
+func (d *symbolPageData) addRefs(h *Hugo, reg *model.Registry, ids ...string) {
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		key := strings.ToLower(id)
+		if _, done := d.Refs[key]; done {
+			continue
+		}
+		target := reg.Get(id)
+		if target == nil {
+			continue
+		}
+		if ref, ok := h.pageRef(target); ok {
+			if d.Refs == nil {
+				d.Refs = make(map[string]pageRef)
+			}
+			d.Refs[key] = ref
+		}
+	}
+}
+
+// collectRefs gathers the pages of everything the symbol references, and
+// splits its parameter, return and exception types into linkable parts.
+func (d *symbolPageData) collectRefs(h *Hugo, reg *model.Registry) {
+	sym := d.Symbol
+	d.addRefs(h, reg, sym.Extends...)
+	d.addRefs(h, reg, sym.Implements...)
+	d.addRefs(h, reg, sym.Traits...)
+	d.addRefs(h, reg, d.Methods...)
+	d.addRefs(h, reg, sym.Doc.SeeAlso...)
+	d.addRefs(h, reg, sym.Uses...)
+	d.addRefs(h, reg, sym.UsedBy...)
+	d.addRefs(h, reg, sym.Overrides)
+	d.addRefs(h, reg, sym.OverriddenBy...)
+	d.addRefs(h, reg, sym.ImplementsMethods...)
+	d.addRefs(h, reg, d.Overridden...)
+	for _, site := range sym.CallSites {
+		d.addRefs(h, reg, site.CallerID)
+	}
+	for _, cb := range sym.Callbacks {
+		d.addRefs(h, reg, cb.CallbackID, cb.CallerID)
+	}
+	for _, g := range d.TraitGroups {
+		d.addRefs(h, reg, g.Trait)
+		for _, m := range g.Members {
+			d.addRefs(h, reg, m.MethodID)
+		}
+	}
+	for _, g := range d.InheritedGroups {
+		d.addRefs(h, reg, g.From)
+		for _, m := range g.Members {
+			d.addRefs(h, reg, m.ID)
+		}
+	}
+	for _, g := range sym.Doc.Globals {
+		d.addRefs(h, reg, "global:"+g.Name)
+	}
+	for _, id := range sym.TypeRefs {
+		d.addRefs(h, reg, id)
+	}
+
+	// Only split types that mention a linkable class
+	linkable := make(map[string]string)
+	for name, id := range sym.TypeRefs {
+		if _, ok := d.Refs[strings.ToLower(id)]; ok {
+			linkable[name] = id
+		}
+	}
+	if len(linkable) == 0 {
+		return
+	}
+	d.ParamTypes = make([][]typePart, len(sym.Params))
+	for i, p := range sym.Params {
+		d.ParamTypes[i] = typeParts(p.Type, linkable)
+	}
+	if sym.Returns != nil {
+		d.ReturnType = typeParts(sym.Returns.Type, linkable)
+	}
+	d.ThrowTypes = make([][]typePart, len(sym.Doc.Throws))
+	for i, t := range sym.Doc.Throws {
+		d.ThrowTypes[i] = typeParts(t.Type, linkable)
+	}
+}
+
+// Plain replaces the inline {@see} and {@link} tags in text with their link
+// text, for front matter fields that are rendered as plain text, such as the
+// summary shown in listings.
+func (d symbolPageData) Plain(text string) string {
+	for _, tag := range d.Doc.Inline {
+		if !strings.Contains(text, tag.Raw) {
+			continue
+		}
+		label := tag.Text
+		if label == "" {
+			label = tag.Target
+		}
+		text = strings.ReplaceAll(text, tag.Raw, label)
+	}
+	return text
+}
+
+// Linked turns the inline {@see} and {@link} tags in text into Markdown links
+// as in Body, for front matter fields the layout renders as Markdown.
+func (d symbolPageData) Linked(text string) string {
+	return d.linkInline(text)
+}
+
+// inlineMarkdown turns the inline tags in text into Markdown links: {@link}
+// URLs become anchors and resolved {@see} targets link to the symbol's page.
+// Unresolved targets are shown as code.
+func (h *Hugo) inlineMarkdown(text string, tags []model.InlineTag, reg *model.Registry) string {
+	for _, tag := range tags {
+		if !strings.Contains(text, tag.Raw) {
+			continue
+		}
+		var md string
+		switch {
+		case tag.IsURL():
+			label := tag.Text
+			if label == "" {
+				label = tag.Target
+			}
+			md = "[" + label + "](" + tag.Target + ")"
+		default:
+			label := tag.Text
+			if label == "" {
+				label = "`" + tag.Target + "`"
+			}
+			md = label
+			if target := reg.Get(tag.TargetID); target != nil {
+				if page := h.pagePath(target); page != "" {
+					md = "[" + label + "](" + page + "/)"
+				}
+			}
+		}
+		text = strings.ReplaceAll(text, tag.Raw, md)
+	}
+	return text
+}
+
+// globalRefData is an @global tag with the page of the global it names.
+type globalRefData struct {
+	model.DocTag
+	Page string
+}
+
+// deprecationData is a deprecation notice with the page of its replacement.
+type deprecationData struct {
+	model.Deprecation
+	ReplacementPage string
+}
+
+// traitGroup lists the methods a class gets from one trait.
+type traitGroup struct {
+	Trait   string
+	Members []traitMemberData
+}
+
+// memberGroup lists the members inherited from one class or interface.
+type memberGroup struct {
+	From    string
+	Members []model.MemberRef
+}
+
+// traitMemberData is a trait method with the name it has in the trait, which
+// differs from Name when the using class aliases it.
+type traitMemberData struct {
+	model.TraitMember
+	Original string
+}
+
+// hookSiteData is a hook call site with its computed source links.
+type hookSiteData struct {
+	model.CallSite
+	GitHubURL     string
+	DocumentedURL string // Link to the canonical documented call site, for duplicate-hook comments
+}
+
+// groupMembers splits the symbol's members by kind so properties, constants
+// and enum cases can be rendered in their own sections, and groups methods
+// gained from traits and inherited members by where they come from.
+func (d *symbolPageData) groupMembers(reg *model.Registry, hidden map[string]bool) {
+	for _, id := range d.Members {
+		if hidden[id] {
+			continue
+		}
+		member := reg.Get(id)
+		if member == nil {
+			d.Methods = append(d.Methods, id)
+			continue
+		}
+		switch member.Kind {
+		case model.KindProperty:
+			d.Properties = append(d.Properties, member)
+		case model.KindConstant:
+			d.Constants = append(d.Constants, member)
+		case model.KindEnumCase:
+			d.Cases = append(d.Cases, member)
+		default:
+			d.Methods = append(d.Methods, id)
+			if v := member.Visibility(); v == "private" || v == "protected" {
+				if d.MemberAccess == nil {
+					d.MemberAccess = make(map[string]string)
+				}
+				d.MemberAccess[id] = v
+			}
+		}
+	}
+
+	groups := make(map[string]int)
+	for _, tm := range d.TraitMembers {
+		i, ok := groups[tm.Trait]
+		if !ok {
+			i = len(d.TraitGroups)
+			groups[tm.Trait] = i
+			d.TraitGroups = append(d.TraitGroups, traitGroup{Trait: tm.Trait})
+		}
+		md := traitMemberData{TraitMember: tm, Original: tm.Name}
+		if method := reg.Get(tm.MethodID); method != nil {
+			md.Original = method.Name
+		}
+		d.TraitGroups[i].Members = append(d.TraitGroups[i].Members, md)
+	}
+
+	// Methods from the class's own traits are already listed above; their
+	// properties and constants are listed with the inherited members.
+	ownTraits := make(map[string]bool)
+	for _, t := range d.Traits {
+		ownTraits[t] = true
+	}
+	inherited := make(map[string]int)
+	for _, ref := range d.AllMembers {
+		switch {
+		case ref.Status == model.MemberOverridden:
+			d.Overridden = append(d.Overridden, ref.ID)
+		case ref.Status == model.MemberInherited && !(ownTraits[ref.Origin] && ref.Kind == model.KindMethod):
+			i, ok := inherited[ref.Origin]
+			if !ok {
+				i = len(d.InheritedGroups)
+				inherited[ref.Origin] = i
+				d.InheritedGroups = append(d.InheritedGroups, memberGroup{From: ref.Origin})
+			}
+			d.InheritedGroups[i].Members = append(d.InheritedGroups[i].Members, ref)
+		}
+	}
 }
 
 // buildSignature constructs a code signature string like the WP developer reference.
@@ -472,6 +1044,14 @@ func buildSignature(sym *model.Symbol) string {
 	switch sym.Kind {
 	case model.KindFunction, model.KindMethod:
 		var b strings.Builder
+		if sym.Kind == model.KindMethod && sym.Language == "php" {
+			// public static function get_instance()
+			for _, m := range sym.Modifiers {
+				b.WriteString(m)
+				b.WriteString(" ")
+			}
+			b.WriteString("function ")
+		}
 		b.WriteString(sym.Name)
 		b.WriteString("( ")
 		for i, p := range sym.Params {
@@ -485,6 +1065,9 @@ func buildSignature(sym *model.Symbol) string {
 			if p.IsPassByRef {
 				b.WriteString("&")
 			}
+			if p.IsVariadic {
+				b.WriteString("...")
+			}
 			b.WriteString("$")
 			b.WriteString(p.Name)
 			if p.Default != "" {
@@ -499,13 +1082,34 @@ func buildSignature(sym *model.Symbol) string {
 		}
 		return b.String()
 
+	case model.KindConstant:
+		// WordPress only uses define() in the global namespace
+		if sym.ParentID != "" || sym.Namespace != "" {
+			return "const " + sym.Name + " = " + sym.Value
+		}
+		return "define( '" + sym.Name + "', " + sym.Value + " )"
+
+	case model.KindGlobal:
+		if sym.Type != "" {
+			return "global " + sym.Type + " " + sym.Name
+		}
+		return "global " + sym.Name
+
 	case model.KindHook:
 		var b strings.Builder
-		if sym.HookType == model.HookAction {
-			b.WriteString("do_action( '")
-		} else {
-			b.WriteString("apply_filters( '")
+		fire, prefix := "do_action", "$"
+		if sym.HookType != model.HookAction {
+			fire = "apply_filters"
 		}
+		if sym.Language == "js" {
+			// applyFilters( 'blocks.registerBlockType', Object settings )
+			fire, prefix = "doAction", ""
+			if sym.HookType != model.HookAction {
+				fire = "applyFilters"
+			}
+		}
+		b.WriteString(fire)
+		b.WriteString("( '")
 		b.WriteString(sym.HookTag)
 		b.WriteString("'")
 		for _, p := range sym.Params {
@@ -514,17 +1118,72 @@ func buildSignature(sym *model.Symbol) string {
 				b.WriteString(p.Type)
 				b.WriteString(" ")
 			}
-			b.WriteString("$")
+			b.WriteString(prefix)
 			b.WriteString(p.Name)
 		}
 		b.WriteString(" )")
 		return b.String()
 
+	case model.KindComponent:
+		// <Button label={ string } size={ 'small' | 'default' } />
+		var b strings.Builder
+		b.WriteString("<")
+		b.WriteString(sym.Name)
+		for _, p := range sym.Props {
+			b.WriteString(" ")
+			b.WriteString(p.Name)
+			if p.Type != "" {
+				b.WriteString("={ ")
+				b.WriteString(p.Type)
+				b.WriteString(" }")
+			}
+		}
+		b.WriteString(" />")
+		return b.String()
+
+	case model.KindTypeAlias:
+		// type WPBlockType, type Alias = string | number, and for a
+		// @callback, type Callback = ( blockName: string ) => boolean
+		var b strings.Builder
+		b.WriteString("type ")
+		b.WriteString(sym.Name)
+		if sym.Params != nil || sym.Returns != nil {
+			b.WriteString(" = ( ")
+			for i, p := range sym.Params {
+				if i > 0 {
+					b.WriteString(", ")
+				}
+				b.WriteString(p.Name)
+				if p.Type != "" {
+					b.WriteString(": ")
+					b.WriteString(p.Type)
+				}
+			}
+			b.WriteString(" ) => ")
+			if sym.Returns != nil && sym.Returns.Type != "" {
+				b.WriteString(sym.Returns.Type)
+			} else {
+				b.WriteString("void")
+			}
+		} else if sym.Type != "" {
+			b.WriteString(" = ")
+			b.WriteString(sym.Type)
+		}
+		return b.String()
+
 	case model.KindClass, model.KindInterface, model.KindTrait, model.KindEnum:
 		var b strings.Builder
+		for _, m := range sym.Modifiers {
+			b.WriteString(m)
+			b.WriteString(" ")
+		}
 		b.WriteString(string(sym.Kind))
 		b.WriteString(" ")
 		b.WriteString(sym.Name)
+		if sym.Kind == model.KindEnum && sym.Type != "" {
+			b.WriteString(": ")
+			b.WriteString(sym.Type)
+		}
 		if len(sym.Extends) > 0 {
 			b.WriteString(" extends ")
 			b.WriteString(strings.Join(sym.Extends, ", "))
@@ -722,7 +1381,7 @@ const layoutList = `{{ define "main" }}
 <section class="reference-overview">
   <h2>Reference</h2>
   <div class="stats-grid">
-    {{ $refSections := slice "functions" "classes" "methods" "hooks" "interfaces" "traits" "enums" "components" }}
+    {{ $refSections := slice "functions" "classes" "methods" "hooks" "js-hooks" "interfaces" "traits" "enums" "types" "components" "constants" "globals" }}
     {{ range $refSections }}
       {{ $sec := $.GetPage . }}
       {{ with $sec }}
@@ -742,6 +1401,13 @@ const layoutList = `{{ define "main" }}
 <h1>{{ .Title }}</h1>
 <p class="count">{{ len .Pages }} items</p>
 
+{{ if where .Pages "Params.access" "in" (slice "private" "protected") }}
+<label class="listing-filter">
+  <input type="checkbox" onchange="document.querySelector('table.listing').classList.toggle('public-only', this.checked)">
+  Hide private and protected members
+</label>
+{{ end }}
+
 <table class="listing">
   <thead>
     <tr>
@@ -752,7 +1418,7 @@ const layoutList = `{{ define "main" }}
   </thead>
   <tbody>
     {{ range .Pages.ByTitle }}
-    <tr{{ if .Params.deprecated }} class="deprecated-row"{{ end }}>
+    <tr{{ if .Params.deprecated }} class="deprecated-row"{{ end }}{{ with .Params.access }} data-access="{{ . }}"{{ end }}>
       <td>
         <a href="{{ .RelPermalink }}">{{ .Title }}</a>
         {{ with .Params.deprecated }}<span class="badge deprecated">Deprecated</span>{{ end }}
@@ -777,6 +1443,30 @@ const layoutSingle = `{{ define "main" }}
 {{ with .Params.deprecated }}
 <div class="deprecated-notice">
   <strong>This {{ $.Params.symbol_kind }} has been deprecated.</strong> {{ . }}
+  {{ range $.Params.deprecations }}{{ if .replacement_page }}{{ with site.GetPage .replacement_page }}
+  <div class="deprecated-replacement">Replacement: <a href="{{ .RelPermalink }}"><code>{{ .Title }}</code></a></div>
+  {{ end }}{{ end }}{{ end }}
+</div>
+{{ end }}
+{{ range .Params.deprecations }}{{ if eq .function "_deprecated_argument" }}
+<div class="deprecated-notice deprecated-argument">
+  <strong>An argument was deprecated{{ with .version }} in {{ . }}{{ end }}.</strong> {{ .message }}
+</div>
+{{ end }}{{ end }}
+
+{{ with .Params.variants }}
+<div class="variants-notice">
+  <strong>This name is declared in {{ len . }} places.</strong>
+  Which declaration PHP uses depends on the files loaded first (e.g. pluggable functions or <code>function_exists()</code> polyfills).
+  <ul>
+    {{ range . }}
+    <li>
+      {{ if .current }}<strong><code>{{ .file }}:{{ .line }}</code></strong> (this page){{ else }}{{ with site.GetPage .page }}<a href="{{ .RelPermalink }}">{{ end }}<code>{{ .file }}:{{ .line }}</code>{{ with site.GetPage .page }}</a>{{ end }}{{ end }}
+      {{ if .primary }}<span class="param-tag">primary</span>{{ end }}
+      <a href="{{ .github_url }}">source</a>
+    </li>
+    {{ end }}
+  </ul>
 </div>
 {{ end }}
 
@@ -788,11 +1478,11 @@ const layoutSingle = `{{ define "main" }}
 
 <section class="description-section">
   <h2>Description</h2>
-  {{ with .Params.summary }}<p class="summary">{{ . }}</p>{{ end }}
+  {{ with .Params.summary_linked }}<p class="summary">{{ . | markdownify }}</p>{{ else }}{{ with .Params.summary }}<p class="summary">{{ . }}</p>{{ end }}{{ end }}
   {{ with .Content }}<div class="long-description">{{ . }}</div>{{ end }}
   {{ with .Params.see_also }}
   <h3>See also</h3>
-  <ul>{{ range . }}<li><code>{{ . }}</code></li>{{ end }}</ul>
+  <ul>{{ range . }}<li><code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code></li>{{ end }}</ul>
   {{ end }}
   {{ with .Params.links }}
   <ul class="doc-links">{{ range . }}<li><a href="{{ . }}">{{ . }}</a></li>{{ end }}</ul>
@@ -806,12 +1496,33 @@ const layoutSingle = `{{ define "main" }}
     {{ range . }}
     <dt>
       <code>${{ .name }}</code>
-      <span class="param-type"><code>{{ .type }}</code></span>
+      <span class="param-type">{{ partial "type.html" (dict "page" $ "type" .type "parts" .type_parts) }}</span>
       {{ if .variadic }}<span class="param-tag">variadic</span>{{ end }}
       {{ if .pass_by_ref }}<span class="param-tag">by&nbsp;ref</span>{{ end }}
     </dt>
     <dd>
-      {{ .description }}
+      {{ .description | markdownify }}
+      {{ with .default }}<p class="param-default">Default: <code>{{ . }}</code></p>{{ end }}
+      {{ with .fields }}{{ partial "param-fields.html" . }}{{ end }}
+    </dd>
+    {{ end }}
+  </dl>
+</section>
+{{ end }}
+
+{{ with .Params.props }}
+<section class="props-section">
+  <h2>Props</h2>
+  {{ with $.Params.props_type }}<p>Type: <code>{{ . }}</code></p>{{ end }}
+  <dl class="param-list">
+    {{ range . }}
+    <dt>
+      <code>{{ .name }}</code>
+      {{ with .type }}<span class="param-type"><code>{{ . }}</code></span>{{ end }}
+      {{ if .required }}<span class="param-tag">required</span>{{ end }}
+    </dt>
+    <dd>
+      {{ .description | markdownify }}
       {{ with .default }}<p class="param-default">Default: <code>{{ . }}</code></p>{{ end }}
     </dd>
     {{ end }}
@@ -822,40 +1533,216 @@ const layoutSingle = `{{ define "main" }}
 {{ with .Params.returns }}{{ if .type }}
 <section class="return-section">
   <h2>Return</h2>
-  <p><code class="return-type">{{ .type }}</code> {{ .description }}</p>
+  <p><span class="return-type">{{ partial "type.html" (dict "page" $ "type" .type "parts" .type_parts) }}</span> {{ .description | markdownify }}</p>
 </section>
 {{ end }}{{ end }}
 
+{{ with .Params.globals }}
+<section class="globals-section">
+  <h2>Globals</h2>
+  <dl class="param-list">
+    {{ range . }}
+    <dt>
+      <code>{{ partial "ref.html" (dict "page" $ "id" (printf "global:%s" .name) "label" (printf "$%s" .name)) }}</code>
+      {{ with .type }}<span class="param-type"><code>{{ . }}</code></span>{{ end }}
+    </dt>
+    <dd>{{ .description }}</dd>
+    {{ end }}
+  </dl>
+</section>
+{{ end }}
+
+{{ with .Params.throws }}
+<section class="throws-section">
+  <h2>Throws</h2>
+  <dl class="param-list">
+    {{ range . }}
+    <dt>{{ partial "type.html" (dict "page" $ "type" .type "parts" .type_parts) }}</dt>
+    <dd>{{ .description }}</dd>
+    {{ end }}
+  </dl>
+</section>
+{{ end }}
+
+{{ with .Params.examples }}
+<section class="examples-section">
+  <h2>Examples</h2>
+  {{ range . }}<pre class="signature-block"><code>{{ . }}</code></pre>{{ end }}
+</section>
+{{ end }}
+
+{{ if or .Params.value .Params.overridable }}
+<section class="value-section">
+  <h2>Value</h2>
+  {{ with .Params.type }}<p>Type: <code>{{ . }}</code></p>{{ end }}
+  {{ with .Params.value }}<pre class="signature-block"><code>{{ . }}</code></pre>{{ end }}
+  {{ if .Params.overridable }}<p class="overridable">Can be defined in <code>wp-config.php</code> before WordPress sets it.</p>{{ end }}
+</section>
+{{ end }}
+
 {{ if .Params.hook_tag }}
 <section class="hook-section">
   <h2>Hook Details</h2>
   <p>Type: <strong>{{ .Params.hook_type }}</strong></p>
   <p>Tag: <code>{{ .Params.hook_tag }}</code></p>
+  {{ if .Params.dynamic }}<p class="hook-dynamic">This is a dynamic hook: the parts in braces are replaced with runtime values.</p>{{ end }}
+  {{ with .Params.expansions }}
+  <h3>Known expansions</h3>
+  <ul class="member-list">{{ range . }}<li><code>{{ . }}</code></li>{{ end }}</ul>
+  {{ end }}
   {{ with .Params.call_sites }}
   <h3>Fired from</h3>
-  <ul>{{ range . }}<li><code>{{ . }}</code></li>{{ end }}</ul>
+  <ul class="call-site-list">
+    {{ range . }}
+    <li>
+      {{ with .caller }}<code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code>{{ else }}<em>file scope</em>{{ end }}
+      &ndash; <a href="{{ .github_url }}">{{ .file }}:{{ .line }}</a>
+      <div><code>{{ .function }}( '{{ $.Params.hook_tag }}'{{ range .args }}, {{ . }}{{ end }} )</code></div>
+      {{ if .documented_in }}<div class="call-site-doc">Documented in {{ if .documented_url }}<a href="{{ .documented_url }}">{{ .documented_in }}</a>{{ else }}<code>{{ .documented_in }}</code>{{ end }}</div>{{ end }}
+    </li>
+    {{ end }}
+  </ul>
+  {{ end }}
+  {{ with .Params.callbacks }}
+  <h3>Callbacks</h3>
+  <table class="related-table">
+    <thead><tr><th>Callback</th><th>Call</th><th>Priority</th><th>Args</th><th>Registered in</th></tr></thead>
+    <tbody>
+      {{ range . }}
+      <tr>
+        <td><code>{{ partial "ref.html" (dict "page" $ "id" .callback_id "label" .callback) }}</code>{{ with .namespace }} <span class="param-tag">{{ . }}</span>{{ end }}</td>
+        <td><code>{{ .function }}</code></td>
+        <td>{{ .priority }}</td>
+        <td>{{ .accepted_args }}</td>
+        <td>{{ with .caller }}<code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code>{{ else }}<code>{{ .file }}</code>{{ end }}</td>
+      </tr>
+      {{ end }}
+    </tbody>
+  </table>
   {{ end }}
 </section>
 {{ end }}
 
+{{ with .Params.cases }}
+<section class="cases-section">
+  <h2>Cases</h2>
+  <table class="related-table">
+    <thead><tr><th>Case</th><th>Value</th><th>Description</th></tr></thead>
+    <tbody>
+      {{ range . }}
+      <tr>
+        <td><code>{{ .name }}</code></td>
+        <td>{{ with .value }}<code>{{ . }}</code>{{ end }}</td>
+        <td>{{ .summary }}</td>
+      </tr>
+      {{ end }}
+    </tbody>
+  </table>
+</section>
+{{ end }}
+
+{{ with .Params.constants }}
+<section class="constants-section">
+  <h2>Constants</h2>
+  <table class="related-table">
+    <thead><tr><th>Name</th><th>Value</th><th>Description</th></tr></thead>
+    <tbody>
+      {{ range . }}
+      <tr>
+        <td><code>{{ .name }}</code>{{ range .modifiers }} <span class="param-tag">{{ . }}</span>{{ end }}</td>
+        <td><code>{{ .value }}</code></td>
+        <td>{{ .summary }}</td>
+      </tr>
+      {{ end }}
+    </tbody>
+  </table>
+</section>
+{{ end }}
+
+{{ with .Params.properties }}
+<section class="properties-section">
+  <h2>Properties</h2>
+  <dl class="param-list">
+    {{ range . }}
+    <dt>
+      <code>{{ if ne $.Params.language "js" }}${{ end }}{{ .name }}</code>
+      {{ with .type }}<span class="param-type"><code>{{ . }}</code></span>{{ end }}
+      {{ range .modifiers }}<span class="param-tag">{{ . }}</span>{{ end }}
+    </dt>
+    <dd>
+      {{ .summary }}
+      {{ with .default }}<p class="param-default">Default: <code>{{ . }}</code></p>{{ end }}
+    </dd>
+    {{ end }}
+  </dl>
+</section>
+{{ end }}
+
 {{ with .Params.members }}
 <section>
-  <h2>Members</h2>
-  <ul class="member-list">{{ range . }}<li><code>{{ . }}</code></li>{{ end }}</ul>
+  <h2>Methods</h2>
+  <ul class="member-list">{{ range . }}{{ $id := . }}<li><code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code>{{ with $.Params.member_access }}{{ with index . $id }} <span class="param-tag">{{ . }}</span>{{ end }}{{ end }}{{ with $.Params.overridden_members }}{{ if in . $id }} <span class="param-tag">overrides</span>{{ end }}{{ end }}</li>{{ end }}</ul>
+</section>
+{{ end }}
+
+{{ range .Params.trait_methods }}
+<section>
+  <h2>Methods from trait <code>{{ partial "ref.html" (dict "page" $ "id" .trait) }}</code></h2>
+  <ul class="member-list">
+    {{ range .methods }}
+    <li>
+      <code>{{ partial "ref.html" (dict "page" $ "id" .method_id "label" .name) }}</code>
+      {{ if ne .name .original }}<span class="trait-alias">alias of <code>{{ .method_id }}</code></span>{{ end }}
+      {{ with .visibility }}<span class="param-tag">{{ . }}</span>{{ end }}
+    </li>
+    {{ end }}
+  </ul>
 </section>
 {{ end }}
 
 {{ with .Params.extends }}
 <section>
   <h2>Extends</h2>
-  <ul>{{ range . }}<li><code>{{ . }}</code></li>{{ end }}</ul>
+  <ul>{{ range . }}<li><code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code></li>{{ end }}</ul>
+</section>
+{{ end }}
+
+{{ range .Params.inherited }}
+<section class="inherited-section">
+  <h2>Inherited from <code>{{ partial "ref.html" (dict "page" $ "id" .from) }}</code></h2>
+  <ul class="member-list">
+    {{ range .members }}
+    <li><code>{{ partial "ref.html" (dict "page" $ "id" .id "label" (cond (eq .kind "property") (printf "$%s" .name) .name)) }}</code>{{ if ne .kind "method" }} <span class="param-tag">{{ .kind }}</span>{{ end }}</li>
+    {{ end }}
+  </ul>
+</section>
+{{ end }}
+
+{{ with .Params.traits }}
+<section>
+  <h2>Uses Traits</h2>
+  <ul>{{ range . }}<li><code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code></li>{{ end }}</ul>
 </section>
 {{ end }}
 
-{{ with .Params.implements }}
+{{ with .Params.overrides }}
+<section>
+  <h2>Overrides</h2>
+  <ul><li><code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code></li></ul>
+</section>
+{{ end }}
+
+{{ with or .Params.implements .Params.implements_methods }}
 <section>
   <h2>Implements</h2>
-  <ul>{{ range . }}<li><code>{{ . }}</code></li>{{ end }}</ul>
+  <ul>{{ range . }}<li><code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code></li>{{ end }}</ul>
+</section>
+{{ end }}
+
+{{ with .Params.overridden_by }}
+<section>
+  <h2>Overridden by</h2>
+  <ul class="member-list">{{ range . }}<li><code>{{ partial "ref.html" (dict "page" $ "id" .) }}</code></li>{{ end }}</ul>
 </section>
 {{ end }}
 
@@ -879,21 +1766,39 @@ const layoutSingle = `{{ define "main" }}
 {{ if or .Params.uses .Params.used_by }}
 <section class="related-section">
   <h2>Related</h2>
-  {{ with .Params.uses }}
-  <h3>Uses</h3>
+  {{ with .Params.uses.calls }}
+  <h3>Calls</h3>
+  <table class="related-table">
+    <thead><tr><th>Function or method</th></tr></thead>
+    <tbody>
+      {{ range . }}<tr><td><code>{{ partial "ref.html" (dict "page" $ "id" .id "label" .label) }}</code></td></tr>{{ end }}
+    </tbody>
+  </table>
+  {{ end }}
+  {{ with .Params.uses.hooks }}
+  <h3>Hooks</h3>
+  <table class="related-table">
+    <thead><tr><th>Hook</th></tr></thead>
+    <tbody>
+      {{ range . }}<tr><td><code>{{ partial "ref.html" (dict "page" $ "id" .id "label" .label) }}</code></td></tr>{{ end }}
+    </tbody>
+  </table>
+  {{ end }}
+  {{ with .Params.uses.globals }}
+  <h3>Globals</h3>
   <table class="related-table">
-    <thead><tr><th>Function</th></tr></thead>
+    <thead><tr><th>Global</th></tr></thead>
     <tbody>
-      {{ range . }}<tr><td><code>{{ . }}</code></td></tr>{{ end }}
+      {{ range . }}<tr><td><code>{{ partial "ref.html" (dict "page" $ "id" .id "label" .label) }}</code></td></tr>{{ end }}
     </tbody>
   </table>
   {{ end }}
   {{ with .Params.used_by }}
   <h3>Used By</h3>
   <table class="related-table">
-    <thead><tr><th>Function</th></tr></thead>
+    <thead><tr><th>Symbol</th><th>Kind</th></tr></thead>
     <tbody>
-      {{ range . }}<tr><td><code>{{ . }}</code></td></tr>{{ end }}
+      {{ range . }}<tr><td><code>{{ partial "ref.html" (dict "page" $ "id" .id) }}</code></td><td>{{ .kind }}</td></tr>{{ end }}
     </tbody>
   </table>
   {{ end }}
@@ -957,7 +1862,7 @@ const partialNav = `{{ $pathParts := split (strings.TrimPrefix "/" .RelPermalink
     {{ end }}
 
     <div class="nav-section-label">Reference</div>
-    {{ $refSections := slice "functions" "classes" "methods" "hooks" "interfaces" "traits" "enums" "components" }}
+    {{ $refSections := slice "functions" "classes" "methods" "hooks" "js-hooks" "interfaces" "traits" "enums" "types" "components" "constants" "globals" }}
     {{ range $refSections }}
       {{ $sec := $versionPage.GetPage . }}
       {{ with $sec }}
@@ -992,9 +1897,80 @@ const partialMeta = `<div class="meta-bar">
   {{ with .Params.access }}<span class="badge access">{{ . }}</span>{{ end }}
   {{ with .Params.since }}<span class="badge since">Since {{ . }}</span>{{ end }}
   {{ with .Params.deprecated }}<span class="badge deprecated">Deprecated</span>{{ end }}
+  {{ if .Params.dynamic }}<span class="badge dynamic">Dynamic</span>{{ end }}
+  {{ if .Params.overridable }}<span class="badge overridable">Overridable</span>{{ end }}
+  {{ if .Params.internal }}<span class="badge internal">Internal</span>{{ end }}
 </div>
 `
 
+// layoutDuplicates renders the disambiguation page for IDs declared more
+// than once.
+const layoutDuplicates = `{{ define "main" }}
+<article class="wp-reference">
+<h1>{{ .Title }}</h1>
+<p>These names are declared more than once, for example pluggable functions and <code>function_exists()</code> polyfills. Each declaration has its own page.</p>
+<table class="listing">
+  <thead><tr><th>Name</th><th>Kind</th><th>Declarations</th></tr></thead>
+  <tbody>
+    {{ range .Params.duplicates }}
+    <tr>
+      <td><code>{{ .id }}</code></td>
+      <td>{{ .kind }}</td>
+      <td>
+        <ul>
+          {{ range .declarations }}
+          <li>{{ with site.GetPage .page }}<a href="{{ .RelPermalink }}">{{ end }}<code>{{ .file }}:{{ .line }}</code>{{ with site.GetPage .page }}</a>{{ end }}{{ if .primary }} <span class="param-tag">primary</span>{{ end }}</li>
+          {{ end }}
+        </ul>
+      </td>
+    </tr>
+    {{ end }}
+  </tbody>
+</table>
+</article>
+{{ end }}
+`
+
+// partialRef renders a symbol ID (or the given label) as a link to the
+// symbol's page when the page's refs map knows it, else as plain text.
+// Expects a dict with "page", "id" and optionally "label".
+const partialRef = `{{- $label := .label | default .id -}}
+{{- $ref := false -}}
+{{- with .page.Params.refs }}{{ $ref = index . (lower $.id) }}{{ end -}}
+{{- with $ref -}}
+<a href="{{ $.page.FirstSection.RelPermalink }}{{ .section }}/{{ .slug }}/">{{ $label }}</a>
+{{- else -}}
+{{ $label }}
+{{- end -}}`
+
+// partialType renders a type expression with its class names linked, from
+// the type_parts written for parameters, returns and exceptions. Expects a
+// dict with "page", "type" and "parts".
+const partialType = `<code>
+{{- with .parts -}}
+{{ range . }}{{ if .id }}{{ partial "ref.html" (dict "page" $.page "id" .id "label" .text) }}{{ else }}{{ .text }}{{ end }}{{ end }}
+{{- else -}}
+{{ .type }}
+{{- end -}}
+</code>`
+
+// partialParamFields renders hash-notation array keys as a nested definition
+// list, recursing for keys that are themselves documented arrays.
+const partialParamFields = `<dl class="param-list param-fields">
+  {{ range . }}
+  <dt>
+    <code>{{ if .is_variadic }}...{{ end }}{{ .name }}</code>
+    {{ with .type }}<span class="param-type"><code>{{ . }}</code></span>{{ end }}
+  </dt>
+  <dd>
+    {{ .description }}
+    {{ with .default }}<p class="param-default">Default: <code>{{ . }}</code></p>{{ end }}
+    {{ with .fields }}{{ partial "param-fields.html" . }}{{ end }}
+  </dd>
+  {{ end }}
+</dl>
+`
+
 // --- Guide layout templates ---
 
 const layoutGuideList = `{{ define "main" }}
@@ -1208,6 +2184,9 @@ h3 {
 .listing .since { color: #787c82; font-size: 0.85rem; white-space: nowrap; }
 
 .deprecated-row { opacity: 0.65; }
+.listing.public-only tr[data-access="private"],
+.listing.public-only tr[data-access="protected"] { display: none; }
+.listing-filter { display: block; margin-bottom: 0.75rem; font-size: 0.9rem; }
 
 p.count { color: #787c82; margin-bottom: 0.5rem; }
 
@@ -1227,6 +2206,10 @@ p.count { color: #787c82; margin-bottom: 0.5rem; }
 .badge.since { background: #e7f5e7; color: #1e7e1e; }
 .badge.access { background: #fef3cd; color: #856404; }
 .badge.deprecated { background: #fcf0f1; color: var(--wp-red); }
+.badge.dynamic { background: #f0e6f6; color: #6b2c91; }
+.badge.internal { background: #fcf9e8; color: #996800; }
+.badge.overridable { background: #edfaef; color: var(--wp-green); }
+.overridable { color: #787c82; font-style: italic; }
 
 .meta-bar {
   display: flex;
@@ -1250,6 +2233,15 @@ p.count { color: #787c82; margin-bottom: 0.5rem; }
   border-radius: 0 3px 3px 0;
 }
 
+.variants-notice {
+  background: #fcf9e8;
+  border-left: 4px solid #dba617;
+  padding: 0.75rem 1rem;
+  margin: 1rem 0;
+  border-radius: 0 3px 3px 0;
+}
+.deprecated-replacement { margin-top: 0.35rem; }
+
 /* Signature block */
 .signature-section {
   margin: 1rem 0;
@@ -1343,6 +2335,12 @@ p.count { color: #787c82; margin-bottom: 0.5rem; }
   line-height: 1.6;
 }
 
+.param-fields {
+  margin: 0.5rem 0 0;
+  padding-left: 0.75rem;
+  border-left: 2px solid #e0e0e0;
+}
+
 .param-default {
   margin-top: 0.25rem;
   font-size: 0.9rem;
@@ -1489,12 +2487,27 @@ p.count { color: #787c82; margin-bottom: 0.5rem; }
   margin: 0.25rem 0;
 }
 
+.call-site-list li {
+  margin: 0.4rem 0;
+}
+
+.call-site-doc {
+  font-size: 0.85rem;
+  color: #787c82;
+}
+
 /* Members list */
 .member-list {
   column-count: 2;
   column-gap: 2rem;
 }
 
+.trait-alias {
+  font-size: 0.85rem;
+  color: #787c82;
+  margin-left: 0.35rem;
+}
+
 /* General */
 code {
   font-family: var(--font-mono);
@@ -1766,33 +2779,179 @@ symbol_kind: {{ yamlEscape (printf "%s" .Kind) }}
 language: {{ yamlEscape .Language }}
 since: {{ yamlEscape .Doc.Since }}
 deprecated: {{ yamlEscape .Doc.Deprecated }}
-access: {{ yamlEscape .Doc.Access }}
-summary: {{ yamlEscape .Doc.Summary }}
+access: {{ yamlEscape .Visibility }}
+{{- if .Modifiers }}
+modifiers:
+{{- range .Modifiers }}
+  - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+summary: {{ yamlEscape (.Plain .Doc.Summary) }}
+{{- with .Linked .Doc.Summary }}{{ if ne . $.Doc.Summary }}
+summary_linked: {{ yamlEscape . }}
+{{- end }}{{ end }}
 signature: {{ yamlEscape .Signature }}
+{{- if or (eq .Kind "constant") (eq .Kind "global") }}
+type: {{ yamlEscape .Symbol.Type }}
+value: {{ yamlEscape .Symbol.Value }}
+{{- end }}
+{{- if .IsOverridable }}
+overridable: true
+{{- end }}
 {{- if .Symbol.Params }}
 parameters:
-{{- range .Symbol.Params }}
+{{- range $i, $p := .Symbol.Params }}
   - name: {{ yamlEscape .Name }}
     type: {{ yamlEscape .Type }}
-    description: {{ yamlEscape .Description }}
+{{- with $.ParamTypes }}
+    type_parts: {{ toJSON (index . $i) }}
+{{- end }}
+    description: {{ yamlEscape ($.Linked .Description) }}
     default: {{ yamlEscape .Default }}
     variadic: {{ .IsVariadic }}
     pass_by_ref: {{ .IsPassByRef }}
+{{- if .Fields }}
+    fields: {{ toJSON .Fields }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- with .PropsType }}
+props_type: {{ yamlEscape . }}
+{{- end }}
+{{- if .Symbol.Props }}
+props:
+{{- range .Symbol.Props }}
+  - name: {{ yamlEscape .Name }}
+    type: {{ yamlEscape .Type }}
+    description: {{ yamlEscape ($.Linked .Description) }}
+    default: {{ yamlEscape .Default }}
+    optional: {{ .IsOptional }}
+    required: {{ .IsRequired }}
 {{- end }}
 {{- end }}
 {{- if .Returns }}
 returns:
   type: {{ yamlEscape .Returns.Type }}
-  description: {{ yamlEscape .Returns.Description }}
+{{- with .ReturnType }}
+  type_parts: {{ toJSON . }}
+{{- end }}
+  description: {{ yamlEscape (.Linked .Returns.Description) }}
+{{- end }}
+{{- if .TypeRefs }}
+type_refs: {{ toJSON .TypeRefs }}
+{{- end }}
+{{- if .Refs }}
+refs: {{ toJSON .Refs }}
+{{- end }}
+{{- if .GlobalRefs }}
+globals:
+{{- range .GlobalRefs }}
+  - name: {{ yamlEscape .Name }}
+    type: {{ yamlEscape .Type }}
+    description: {{ yamlEscape .Description }}
+    page: {{ yamlEscape .Page }}
+{{- end }}
+{{- end }}
+{{- if .Doc.Throws }}
+throws:
+{{- range $i, $t := .Doc.Throws }}
+  - type: {{ yamlEscape .Type }}
+{{- with $.ThrowTypes }}
+    type_parts: {{ toJSON (index . $i) }}
+{{- end }}
+    description: {{ yamlEscape .Description }}
+{{- end }}
+{{- end }}
+{{- if .Doc.Examples }}
+examples:
+{{- range .Doc.Examples }}
+  - {{ yamlMultiline . }}
+{{- end }}
+{{- end }}
+{{- if .Doc.Todos }}
+todos:
+{{- range .Doc.Todos }}
+  - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- if .Doc.Uses }}
+doc_uses:
+{{- range .Doc.Uses }}
+  - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- if .Doc.Analysis }}
+analysis:
+{{- range .Doc.Analysis }}
+  - tool: {{ yamlEscape .Tool }}
+    tag: {{ yamlEscape .Tag }}
+    value: {{ yamlEscape .Value }}
+{{- end }}
+{{- end }}
+{{- if .Doc.Internal }}
+internal: true
+{{- end }}
+{{- if .Doc.Ignore }}
+ignore: true
 {{- end }}
 hook_type: {{ yamlEscape (printf "%s" .HookType) }}
 hook_tag: {{ yamlEscape .HookTag }}
-{{- if .CallSites }}
-call_sites:
-{{- range .CallSites }}
+{{- if .IsDynamic }}
+dynamic: true
+{{- end }}
+{{- if .Expansions }}
+expansions:
+{{- range .Expansions }}
   - {{ yamlEscape . }}
 {{- end }}
 {{- end }}
+{{- if .HookSites }}
+call_sites:
+{{- range .HookSites }}
+  - caller: {{ yamlEscape .CallerID }}
+    function: {{ yamlEscape .Function }}
+    file: {{ yamlEscape .File }}
+    line: {{ .Line }}
+    github_url: {{ yamlEscape .GitHubURL }}
+{{- if .DocumentedIn }}
+    documented_in: {{ yamlEscape .DocumentedIn }}
+    documented_url: {{ yamlEscape .DocumentedURL }}
+{{- end }}
+{{- if .Args }}
+    args:
+{{- range .Args }}
+      - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .Callbacks }}
+callbacks:
+{{- range .Callbacks }}
+  - function: {{ yamlEscape .Function }}
+    callback: {{ yamlEscape .Callback }}
+    callback_id: {{ yamlEscape .CallbackID }}
+    priority: {{ yamlEscape .Priority }}
+    accepted_args: {{ yamlEscape .AcceptedArgs }}
+    namespace: {{ yamlEscape .Namespace }}
+    caller: {{ yamlEscape .CallerID }}
+    file: {{ yamlEscape .File }}
+    line: {{ .Line }}
+{{- end }}
+{{- end }}
+{{- if .DeprecationNotices }}
+deprecations:
+{{- range .DeprecationNotices }}
+  - function: {{ yamlEscape .Function }}
+    version: {{ yamlEscape .Version }}
+    replacement: {{ yamlEscape .Replacement }}
+    replacement_id: {{ yamlEscape .ReplacementID }}
+    replacement_page: {{ yamlEscape .ReplacementPage }}
+    message: {{ yamlEscape .Message }}
+    file: {{ yamlEscape .File }}
+    line: {{ .Line }}
+{{- end }}
+{{- end }}
 {{- if .Extends }}
 extends:
 {{- range .Extends }}
@@ -1805,26 +2964,146 @@ implements:
   - {{ yamlEscape . }}
 {{- end }}
 {{- end }}
-{{- if .Members }}
+{{- if .Methods }}
 members:
+{{- range .Methods }}
+  - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- if .MemberAccess }}
+member_access: {{ toJSON .MemberAccess }}
+{{- end }}
+{{- if .Traits }}
+traits:
+{{- range .Traits }}
+  - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- if .TraitGroups }}
+trait_methods:
+{{- range .TraitGroups }}
+  - trait: {{ yamlEscape .Trait }}
+    methods:
+{{- range .Members }}
+      - name: {{ yamlEscape .Name }}
+        method_id: {{ yamlEscape .MethodID }}
+        original: {{ yamlEscape .Original }}
+        visibility: {{ yamlEscape .Visibility }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .InheritedGroups }}
+inherited:
+{{- range .InheritedGroups }}
+  - from: {{ yamlEscape .From }}
+    members:
 {{- range .Members }}
+      - name: {{ yamlEscape .Name }}
+        id: {{ yamlEscape .ID }}
+        kind: {{ yamlEscape (printf "%s" .Kind) }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .Overridden }}
+overridden_members:
+{{- range .Overridden }}
   - {{ yamlEscape . }}
 {{- end }}
 {{- end }}
+{{- if .Cases }}
+cases:
+{{- range .Cases }}
+  - name: {{ yamlEscape .Name }}
+    value: {{ yamlEscape .Value }}
+    summary: {{ yamlEscape .Doc.Summary }}
+{{- end }}
+{{- end }}
+{{- if .Constants }}
+constants:
+{{- range .Constants }}
+  - name: {{ yamlEscape .Name }}
+    type: {{ yamlEscape .Type }}
+    value: {{ yamlEscape .Value }}
+    summary: {{ yamlEscape .Doc.Summary }}
+{{- if .Modifiers }}
+    modifiers:
+{{- range .Modifiers }}
+      - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- if .Properties }}
+properties:
+{{- range .Properties }}
+  - name: {{ yamlEscape .Name }}
+    type: {{ yamlEscape .Type }}
+    default: {{ yamlEscape .Value }}
+    summary: {{ yamlEscape .Doc.Summary }}
+{{- if .Modifiers }}
+    modifiers:
+{{- range .Modifiers }}
+      - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- end }}
 parent_id: {{ yamlEscape .ParentID }}
-{{- if .UsedBy }}
+{{- if .UsedByRefs }}
 used_by:
-{{- range .UsedBy }}
-  - {{ yamlEscape . }}
+{{- range .UsedByRefs }}
+  - id: {{ yamlEscape .ID }}
+    kind: {{ yamlEscape .Kind }}
 {{- end }}
 {{- end }}
 {{- if .Uses }}
 uses:
-{{- range .Uses }}
-  - {{ yamlEscape . }}
+{{- with .UsesGroups.Calls }}
+  calls:
+{{- range . }}
+    - id: {{ yamlEscape .ID }}
+      label: {{ yamlEscape .Label }}
+{{- end }}
+{{- end }}
+{{- with .UsesGroups.Hooks }}
+  hooks:
+{{- range . }}
+    - id: {{ yamlEscape .ID }}
+      label: {{ yamlEscape .Label }}
+{{- end }}
+{{- end }}
+{{- with .UsesGroups.Globals }}
+  globals:
+{{- range . }}
+    - id: {{ yamlEscape .ID }}
+      label: {{ yamlEscape .Label }}
+{{- end }}
 {{- end }}
 {{- end }}
 overrides: {{ yamlEscape .Overrides }}
+{{- if .OverriddenBy }}
+overridden_by:
+{{- range .OverriddenBy }}
+  - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- if .ImplementsMethods }}
+implements_methods:
+{{- range .ImplementsMethods }}
+  - {{ yamlEscape . }}
+{{- end }}
+{{- end }}
+{{- if .Variants }}
+variants:
+{{- range .Variants }}
+  - file: {{ yamlEscape .Location.File }}
+    line: {{ .Location.StartLine }}
+    page: {{ yamlEscape .Page }}
+    github_url: {{ yamlEscape .GitHubURL }}
+    primary: {{ .Primary }}
+    current: {{ eq .Slug $.Slug }}
+{{- end }}
+{{- end }}
 {{- if .Doc.SeeAlso }}
 see_also:
 {{- range .Doc.SeeAlso }}
@@ -1854,7 +3133,7 @@ source_code: {{ yamlMultiline .SourceCode }}
 {{- end }}
 ---
 
-{{ safeContent .Doc.Description }}
+{{ safeContent .Body }}
 {{- if .OverrideContent }}
 
 <div class="override-content">
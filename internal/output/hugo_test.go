@@ -0,0 +1,69 @@
+package output
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestGroupUses(t *testing.T) {
+	reg := model.NewRegistry()
+	reg.Add(&model.Symbol{ID: "caller", Name: "caller", Kind: model.KindFunction, Language: "php"})
+	reg.Add(&model.Symbol{ID: "WP::run", Name: "run", Kind: model.KindMethod, Language: "php"})
+
+	d := symbolPageData{Symbol: &model.Symbol{
+		ID:     "f",
+		Uses:   []string{"wp_die", "hook:init", "WP::run", "global:wpdb", "jshook:blocks.registerBlockType"},
+		UsedBy: []string{"caller", "WP::run", "gone"},
+	}}
+	d.groupUses(reg)
+
+	want := usesData{
+		Calls:   []relatedRef{{ID: "wp_die", Label: "wp_die"}, {ID: "WP::run", Label: "WP::run"}},
+		Hooks:   []relatedRef{{ID: "hook:init", Label: "init"}, {ID: "jshook:blocks.registerBlockType", Label: "blocks.registerBlockType"}},
+		Globals: []relatedRef{{ID: "global:wpdb", Label: "$wpdb"}},
+	}
+	if !reflect.DeepEqual(d.UsesGroups, want) {
+		t.Errorf("UsesGroups = %+v, want %+v", d.UsesGroups, want)
+	}
+	wantUsedBy := []relatedRef{
+		{ID: "caller", Label: "caller", Kind: "function"},
+		{ID: "WP::run", Label: "WP::run", Kind: "method"},
+		{ID: "gone", Label: "gone"},
+	}
+	if !reflect.DeepEqual(d.UsedByRefs, wantUsedBy) {
+		t.Errorf("UsedByRefs = %+v, want %+v", d.UsedByRefs, wantUsedBy)
+	}
+}
+
+func TestInlineMarkdown(t *testing.T) {
+	reg := model.NewRegistry()
+	reg.Add(&model.Symbol{ID: "hook:init", Name: "init", Kind: model.KindHook, Language: "php", HookTag: "init"})
+	reg.Add(&model.Symbol{ID: "get_post", Name: "get_post", Kind: model.KindFunction, Language: "php"})
+	h := NewHugo("", "", "6.7.1", "", "")
+
+	tags := []model.InlineTag{
+		{Raw: "{@see 'init'}", Tag: "see", Target: "'init'", TargetID: "hook:init"},
+		{Raw: "{@see get_post() the post}", Tag: "see", Target: "get_post()", Text: "the post", TargetID: "get_post"},
+		{Raw: "{@see missing()}", Tag: "see", Target: "missing()"},
+		{Raw: "{@link https://example.com/}", Tag: "link", Target: "https://example.com/"},
+		{Raw: "{@link https://example.com/docs Docs}", Tag: "link", Target: "https://example.com/docs", Text: "Docs"},
+	}
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Fires {@see 'init'}.", "Fires [`'init'`](/6.7/hooks/hookinit/)."},
+		{"Returns {@see get_post() the post}.", "Returns [the post](/6.7/functions/get_post/)."},
+		{"Like {@see missing()}.", "Like `missing()`."},
+		{"At {@link https://example.com/}.", "At [https://example.com/](https://example.com/)."},
+		{"Read {@link https://example.com/docs Docs}.", "Read [Docs](https://example.com/docs)."},
+		{"No tags.", "No tags."},
+	}
+	for _, tt := range tests {
+		if got := h.inlineMarkdown(tt.text, tags, reg); got != tt.want {
+			t.Errorf("inlineMarkdown(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
@@ -28,6 +28,12 @@ func findDocComment(node *sitter.Node, src []byte) model.DocBlock {
 
 	raw := chunk[idx : idx+endIdx+2]
 
+	// A @typedef or @callback block declares a type of its own rather than
+	// documenting the code after it.
+	if isTypedefBlock(raw) {
+		return model.DocBlock{}
+	}
+
 	// Make sure there's no code between the docblock and the node
 	between := strings.TrimSpace(chunk[idx+endIdx+2:])
 	if between != "" && !isOnlyWhitespaceOrModifiers(between) {
@@ -2,16 +2,32 @@ package parser
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/peter/wpdocs/internal/model"
 )
 
 var (
-	tagRegex    = regexp.MustCompile(`^@(\w+)\s*(.*)$`)
+	tagRegex    = regexp.MustCompile(`^@([\w-]+)\s*(.*)$`)
 	paramRegex  = regexp.MustCompile(`^@param\s+(\S+)\s+(\$\w+)\s*(.*)$`)
 	returnRegex = regexp.MustCompile(`^@return\s+(\S+)\s*(.*)$`)
 	sinceRegex  = regexp.MustCompile(`^@since\s+(.+)$`)
+
+	// "Type $name Description", with the type optional for @global and the
+	// name optional for @var and @type.
+	typedTagRegex = regexp.MustCompile(`^(?:([^\s$]\S*)\s*)?(?:\$(\w+))?\s*(.*)$`)
+
+	// {@internal ...}} notes are for core developers and never published.
+	// Note the doubled closing brace.
+	inlineInternalRegex = regexp.MustCompile(`\{@internal[\s\S]*?\}\}`)
+
+	// {@see WP_Query::query()}, {@link https://example.com Link text}
+	inlineTagRegex = regexp.MustCompile(`\{@(see|link)\s+([^\s}]+)(?:\s+([^}]*))?\}`)
+
+	// WordPress ends optional @param descriptions with a sentence such as
+	// "Default 'publish'." or "Default is global $post."
+	docDefaultRegex = regexp.MustCompile(`(?:^|[.!?]\s+)Default(?:\s+is)?(?:\s+value\s+is)?:?\s+(.+?)\.?\s*$`)
 )
 
 // ParseDocBlock parses a PHPDoc comment block into a structured DocBlock.
@@ -23,6 +39,7 @@ func ParseDocBlock(raw string) model.DocBlock {
 	// Strip comment delimiters
 	raw = strings.TrimPrefix(raw, "/**")
 	raw = strings.TrimSuffix(raw, "*/")
+	raw = inlineInternalRegex.ReplaceAllString(raw, "")
 
 	lines := strings.Split(raw, "\n")
 	var cleaned []string
@@ -50,7 +67,9 @@ func ParseDocBlock(raw string) model.DocBlock {
 	)
 
 	flushTag := func() {
-		if currentTag != "" {
+		if currentTag == "example" {
+			doc.Tags[currentTag] = append(doc.Tags[currentTag], exampleBody(tagLines))
+		} else if currentTag != "" {
 			// Trim each continuation line to collapse aligned whitespace
 			var trimmed []string
 			for _, tl := range tagLines {
@@ -101,8 +120,8 @@ func ParseDocBlock(raw string) model.DocBlock {
 		}
 
 		if inTags {
-			// Continuation of a tag
-			if line != "" {
+			// Continuation of a tag; examples keep their blank lines
+			if line != "" || currentTag == "example" {
 				tagLines = append(tagLines, line)
 			}
 			continue
@@ -125,13 +144,114 @@ func ParseDocBlock(raw string) model.DocBlock {
 		}
 	}
 	flushTag()
+	parseStructuredTags(&doc)
 
 	doc.Summary = strings.TrimSpace(strings.Join(summary, " "))
 	doc.Description = strings.TrimSpace(strings.Join(description, "\n"))
+	texts := []string{doc.Summary, doc.Description}
+	texts = append(texts, doc.Tags["param"]...)
+	texts = append(texts, doc.Tags["return"]...)
+	doc.Inline = parseInlineTags(texts...)
 
 	return doc
 }
 
+// parseStructuredTags fills the DocBlock's typed fields from its raw tags.
+func parseStructuredTags(doc *model.DocBlock) {
+	for _, raw := range doc.Tags["global"] {
+		if tag := parseTypedTag(raw); tag.Name != "" {
+			doc.Globals = append(doc.Globals, tag)
+		}
+	}
+	for _, raw := range doc.Tags["throws"] {
+		typ, desc, _ := strings.Cut(raw, " ")
+		doc.Throws = append(doc.Throws, model.DocTag{Type: typ, Description: strings.TrimSpace(desc)})
+	}
+	for _, raw := range doc.Tags["type"] {
+		doc.Types = append(doc.Types, parseTypedTag(raw))
+	}
+	if vars := doc.Tags["var"]; len(vars) > 0 {
+		tag := parseTypedTag(vars[0])
+		doc.Var = &tag
+	}
+	for _, raw := range doc.Tags["uses"] {
+		if raw != "" {
+			doc.Uses = append(doc.Uses, raw)
+		}
+	}
+	doc.Todos = append(doc.Todos, doc.Tags["todo"]...)
+	doc.Examples = append(doc.Examples, doc.Tags["example"]...)
+	_, doc.Ignore = doc.Tags["ignore"]
+	_, doc.Internal = doc.Tags["internal"]
+
+	// Sorted so the order doesn't depend on map iteration
+	var analysis []string
+	for name := range doc.Tags {
+		if strings.HasPrefix(name, "phpstan-") || strings.HasPrefix(name, "psalm-") {
+			analysis = append(analysis, name)
+		}
+	}
+	sort.Strings(analysis)
+	for _, name := range analysis {
+		tool, tag, _ := strings.Cut(name, "-")
+		for _, value := range doc.Tags[name] {
+			doc.Analysis = append(doc.Analysis, model.AnalysisTag{Tool: tool, Tag: tag, Value: value})
+		}
+	}
+}
+
+// parseInlineTags collects the distinct inline {@see} and {@link} tags in the
+// given texts, in order of appearance.
+func parseInlineTags(texts ...string) []model.InlineTag {
+	var tags []model.InlineTag
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, m := range inlineTagRegex.FindAllStringSubmatch(text, -1) {
+			if !seen[m[0]] {
+				seen[m[0]] = true
+				tags = append(tags, model.InlineTag{Raw: m[0], Tag: m[1], Target: m[2], Text: strings.TrimSpace(m[3])})
+			}
+		}
+	}
+	return tags
+}
+
+// parseTypedTag splits a "Type $name Description" tag body.
+func parseTypedTag(raw string) model.DocTag {
+	m := typedTagRegex.FindStringSubmatch(strings.TrimSpace(raw))
+	if m == nil {
+		return model.DocTag{Description: raw}
+	}
+	return model.DocTag{Type: m[1], Name: m[2], Description: strings.TrimSpace(m[3])}
+}
+
+// exampleBody joins the lines of an @example tag, dropping Markdown code
+// fences and the indentation common to every line.
+func exampleBody(lines []string) string {
+	var code []string
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			continue
+		}
+		code = append(code, strings.TrimRight(line, " \t"))
+	}
+	indent := -1
+	for _, line := range code {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if n := len(line) - len(strings.TrimLeft(line, " \t")); indent < 0 || n < indent {
+			indent = n
+		}
+	}
+	for i, line := range code {
+		if len(line) >= indent && indent > 0 {
+			code[i] = line[indent:]
+		}
+	}
+	return strings.Trim(strings.Join(code, "\n"), "\n")
+}
+
 // ParseParams extracts @param tags into structured Param entries.
 func ParseParams(doc model.DocBlock) []model.Param {
 	var params []model.Param
@@ -146,12 +266,101 @@ func ParseParams(doc model.DocBlock) []model.Param {
 				p.IsNullable = true
 				p.Type = strings.TrimPrefix(p.Type, "?")
 			}
+			if desc, fields, ok := parseHashNotation(p.Description); ok {
+				p.Description = desc
+				p.Fields = fields
+			}
 			params = append(params, p)
 		}
 	}
 	return params
 }
 
+// parseHashNotation parses a WordPress hash-notation description such as
+//
+//	{ Optional. Array of arguments. @type string $name Description. @type array $labels { ... } }
+//
+// into its leading description and structured fields. Multi-line tags have
+// already been flattened by ParseDocBlock, so structure is recovered from the
+// standalone "{", "}" and "@type" tokens.
+func parseHashNotation(text string) (string, []model.Param, bool) {
+	tokens := strings.Fields(text)
+	if len(tokens) == 0 || tokens[0] != "{" {
+		return text, nil, false
+	}
+	desc, fields, _ := parseHashBlock(tokens, 1)
+	return desc, fields, true
+}
+
+// parseHashBlock consumes tokens up to and including the closing "}" of a block.
+func parseHashBlock(tokens []string, pos int) (string, []model.Param, int) {
+	var (
+		words  []string
+		fields []model.Param
+	)
+	for pos < len(tokens) {
+		switch tok := tokens[pos]; {
+		case tok == "}":
+			return strings.Join(words, " "), fields, pos + 1
+		case tok == "@type":
+			var field model.Param
+			field, pos = parseHashField(tokens, pos+1)
+			fields = append(fields, field)
+		default:
+			if len(fields) == 0 {
+				words = append(words, tok)
+			}
+			pos++
+		}
+	}
+	return strings.Join(words, " "), fields, pos
+}
+
+// parseHashField consumes one "@type Type $name Description" entry, including
+// a nested block if the description opens one.
+func parseHashField(tokens []string, pos int) (model.Param, int) {
+	var p model.Param
+	if pos < len(tokens) && tokens[pos] != "}" {
+		p.Type = tokens[pos]
+		pos++
+	}
+	if pos < len(tokens) && strings.HasPrefix(tokens[pos], "$") {
+		p.Name = strings.TrimPrefix(tokens[pos], "$")
+		pos++
+	} else if pos < len(tokens) && strings.HasPrefix(tokens[pos], "...$") {
+		// Any number of entries: `@type array ...$0 { ... }`
+		p.Name = strings.TrimPrefix(tokens[pos], "...$")
+		p.IsVariadic = true
+		pos++
+	}
+
+	var words []string
+	for pos < len(tokens) {
+		tok := tokens[pos]
+		if tok == "@type" || tok == "}" {
+			break
+		}
+		if tok == "{" {
+			var desc string
+			desc, p.Fields, pos = parseHashBlock(tokens, pos+1)
+			if desc != "" {
+				words = append(words, desc)
+			}
+			continue
+		}
+		words = append(words, tok)
+		pos++
+	}
+
+	p.Description = strings.Join(words, " ")
+	p.Default = ParseDocDefault(p.Description)
+	if strings.HasPrefix(p.Type, "?") {
+		p.IsNullable = true
+		p.Type = strings.TrimPrefix(p.Type, "?")
+	}
+	return p, pos
+}
+
 // ParseReturn extracts @return tag into a ReturnValue.
 func ParseReturn(doc model.DocBlock) *model.ReturnValue {
 	returns := doc.Tags["return"]
@@ -167,3 +376,21 @@ func ParseReturn(doc model.DocBlock) *model.ReturnValue {
 	}
 	return &model.ReturnValue{Type: raw}
 }
+
+// ParseVar extracts the type and description from a @var tag
+// ("@var Type $name Description" or "@var Type Description").
+func ParseVar(doc model.DocBlock) (typeName, description string) {
+	if doc.Var == nil {
+		return "", ""
+	}
+	return doc.Var.Type, doc.Var.Description
+}
+
+// ParseDocDefault extracts the default value from a WordPress-style
+// "Default ..." sentence at the end of a parameter description.
+func ParseDocDefault(description string) string {
+	if m := docDefaultRegex.FindStringSubmatch(description); m != nil {
+		return strings.TrimSpace(m[1])
+	}
+	return ""
+}
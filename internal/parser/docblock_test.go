@@ -0,0 +1,166 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestParseHashNotation(t *testing.T) {
+	doc := ParseDocBlock(`/**
+ * Retrieves posts.
+ *
+ * @param array $args {
+ *     Optional. Arguments to retrieve posts. Default empty array.
+ *
+ *     @type int      $numberposts Total number of posts. Default 5.
+ *     @type ?string  $orderby     Field to order by.
+ *     @type array    $tax_query {
+ *         Taxonomy query clauses.
+ *
+ *         @type string $relation Relation between clauses. Default 'AND'.
+ *         @type array  ...$0 {
+ *             A clause.
+ *
+ *             @type string $taxonomy Taxonomy slug.
+ *         }
+ *     }
+ *     @type bool     $suppress_filters Whether to suppress filters. Default true.
+ * }
+ * @param string $plain A parameter without hash notation. Default 'raw'.
+ * @return WP_Post[] Posts.
+ */`)
+
+	want := []model.Param{
+		{
+			Name:        "args",
+			Type:        "array",
+			Description: "Optional. Arguments to retrieve posts. Default empty array.",
+			Fields: []model.Param{
+				{Name: "numberposts", Type: "int", Description: "Total number of posts. Default 5.", Default: "5"},
+				{Name: "orderby", Type: "string", Description: "Field to order by.", IsNullable: true},
+				{Name: "tax_query", Type: "array", Description: "Taxonomy query clauses.", Fields: []model.Param{
+					{Name: "relation", Type: "string", Description: "Relation between clauses. Default 'AND'.", Default: "'AND'"},
+					{Name: "0", Type: "array", Description: "A clause.", IsVariadic: true, Fields: []model.Param{
+						{Name: "taxonomy", Type: "string", Description: "Taxonomy slug."},
+					}},
+				}},
+				{Name: "suppress_filters", Type: "bool", Description: "Whether to suppress filters. Default true.", Default: "true"},
+			},
+		},
+		{Name: "plain", Type: "string", Description: "A parameter without hash notation. Default 'raw'."},
+	}
+	if got := ParseParams(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseParams() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestParseDocDefault(t *testing.T) {
+	tests := []struct {
+		desc, want string
+	}{
+		{"Post status. Default 'publish'.", "'publish'"},
+		{"The post. Default is global $post.", "global $post"},
+		{"Whether to echo. Default: true", "true"},
+		{"Optional. Default value is 10.", "10"},
+		{"Default empty.", "empty"},
+		{"Defaults to nothing in particular.", ""},
+		{"The default post.", ""},
+	}
+	for _, tt := range tests {
+		if got := ParseDocDefault(tt.desc); got != tt.want {
+			t.Errorf("ParseDocDefault(%q) = %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestParseStructuredTags(t *testing.T) {
+	doc := ParseDocBlock(`/**
+ * Does things.
+ *
+ * @since 2.0.0
+ * @since 5.5.0 Added the $args parameter.
+ *
+ * @global wpdb     $wpdb WordPress database abstraction object.
+ * @global WP_Query $wp_query
+ * @global          $untyped Untyped global.
+ * @uses wp_cache_get()
+ * @throws InvalidArgumentException When the ID is not valid.
+ * @todo Cache the result.
+ * @var int $count Counter.
+ * @phpstan-param array<string, int> $args
+ * @psalm-return list<int>
+ * @internal
+ * @example
+ *     ` + "```php" + `
+ *     $x = f( 1 );
+ *
+ *     echo $x;
+ *     ` + "```" + `
+ */`)
+
+	if doc.Since != "2.0.0" || len(doc.Tags["since"]) != 2 {
+		t.Errorf("since = %q (%d tags), want 2.0.0 (2 tags)", doc.Since, len(doc.Tags["since"]))
+	}
+	wantGlobals := []model.DocTag{
+		{Type: "wpdb", Name: "wpdb", Description: "WordPress database abstraction object."},
+		{Type: "WP_Query", Name: "wp_query"},
+		{Name: "untyped", Description: "Untyped global."},
+	}
+	if !reflect.DeepEqual(doc.Globals, wantGlobals) {
+		t.Errorf("globals = %+v, want %+v", doc.Globals, wantGlobals)
+	}
+	if want := []string{"wp_cache_get()"}; !reflect.DeepEqual(doc.Uses, want) {
+		t.Errorf("uses = %q, want %q", doc.Uses, want)
+	}
+	if want := []model.DocTag{{Type: "InvalidArgumentException", Description: "When the ID is not valid."}}; !reflect.DeepEqual(doc.Throws, want) {
+		t.Errorf("throws = %+v, want %+v", doc.Throws, want)
+	}
+	if want := []string{"Cache the result."}; !reflect.DeepEqual(doc.Todos, want) {
+		t.Errorf("todos = %q, want %q", doc.Todos, want)
+	}
+	if want := (&model.DocTag{Type: "int", Name: "count", Description: "Counter."}); !reflect.DeepEqual(doc.Var, want) {
+		t.Errorf("var = %+v, want %+v", doc.Var, want)
+	}
+	wantAnalysis := []model.AnalysisTag{
+		{Tool: "phpstan", Tag: "param", Value: "array<string, int> $args"},
+		{Tool: "psalm", Tag: "return", Value: "list<int>"},
+	}
+	if !reflect.DeepEqual(doc.Analysis, wantAnalysis) {
+		t.Errorf("analysis = %+v, want %+v", doc.Analysis, wantAnalysis)
+	}
+	if want := []string{"$x = f( 1 );\n\necho $x;"}; !reflect.DeepEqual(doc.Examples, want) {
+		t.Errorf("examples = %q, want %q", doc.Examples, want)
+	}
+	if !doc.Internal || doc.Ignore {
+		t.Errorf("internal = %v, ignore = %v, want internal only", doc.Internal, doc.Ignore)
+	}
+}
+
+func TestParseInlineTags(t *testing.T) {
+	doc := ParseDocBlock(`/**
+ * Runs the query. {@internal Keep in sync with
+ * get_posts().}} See {@see WP_Query::query()}.
+ *
+ * Fires {@see 'pre_get_posts'} first. Details at
+ * {@link https://developer.wordpress.org/ the handbook}.
+ *
+ * @param array $args Arguments, as in {@see WP_Query::query()}.
+ * @return WP_Post[] Posts, see {@see get_post()}.
+ */`)
+
+	if strings.Contains(doc.Summary, "internal") || strings.Contains(doc.Summary, "get_posts") {
+		t.Errorf("Summary = %q, want the {@internal} note left out", doc.Summary)
+	}
+	want := []model.InlineTag{
+		{Raw: "{@see WP_Query::query()}", Tag: "see", Target: "WP_Query::query()"},
+		{Raw: "{@see 'pre_get_posts'}", Tag: "see", Target: "'pre_get_posts'"},
+		{Raw: "{@link https://developer.wordpress.org/ the handbook}", Tag: "link", Target: "https://developer.wordpress.org/", Text: "the handbook"},
+		{Raw: "{@see get_post()}", Tag: "see", Target: "get_post()"},
+	}
+	if !reflect.DeepEqual(doc.Inline, want) {
+		t.Errorf("Inline =\n%+v\nwant\n%+v", doc.Inline, want)
+	}
+}
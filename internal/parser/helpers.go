@@ -59,3 +59,43 @@ func walkTree(node *sitter.Node, fn func(*sitter.Node)) {
 		walkTree(node.NamedChild(i), fn)
 	}
 }
+
+// walkTreePruned performs a depth-first traversal like walkTree, but does not
+// descend into (or call fn on) nodes for which prune returns true.
+func walkTreePruned(node *sitter.Node, prune func(*sitter.Node) bool, fn func(*sitter.Node)) {
+	if node == nil || prune(node) {
+		return
+	}
+	fn(node)
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		walkTreePruned(node.NamedChild(i), prune, fn)
+	}
+}
+
+// callArguments returns the argument expressions of a call node, unwrapping
+// PHP argument nodes (including named arguments) to their value expression.
+func callArguments(call *sitter.Node) []*sitter.Node {
+	args := call.ChildByFieldName("arguments")
+	if args == nil {
+		return nil
+	}
+	var result []*sitter.Node
+	for i := 0; i < int(args.NamedChildCount()); i++ {
+		arg := args.NamedChild(i)
+		if arg.Type() == "argument" && arg.NamedChildCount() > 0 {
+			arg = arg.NamedChild(int(arg.NamedChildCount()) - 1)
+		}
+		result = append(result, arg)
+	}
+	return result
+}
+
+// appendUnique appends val to slice unless it is already present.
+func appendUnique(slice []string, val string) []string {
+	for _, s := range slice {
+		if s == val {
+			return slice
+		}
+	}
+	return append(slice, val)
+}
@@ -0,0 +1,47 @@
+package parser
+
+import (
+	"context"
+	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/php"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// parseTree parses src with the given grammar and frees the tree when the
+// test ends.
+func parseTree(t *testing.T, lang *sitter.Language, src string) *sitter.Node {
+	t.Helper()
+	sp := sitter.NewParser()
+	defer sp.Close()
+	sp.SetLanguage(lang)
+	tree, err := sp.ParseCtx(context.Background(), nil, []byte(src))
+	if err != nil {
+		t.Fatalf("parsing: %v", err)
+	}
+	t.Cleanup(tree.Close)
+	return tree.RootNode()
+}
+
+// extractPHPSource extracts the symbols of a PHP file into a new registry.
+func extractPHPSource(t *testing.T, file, src string) *model.Registry {
+	t.Helper()
+	reg := model.NewRegistry()
+	extractPHP(parseTree(t, php.GetLanguage(), src), []byte(src), file, reg)
+	return reg
+}
+
+// extractJSSource extracts the symbols of a JS or TS file into a new
+// registry, with the grammar chosen by the file's extension.
+func extractJSSource(t *testing.T, file, src string) *model.Registry {
+	t.Helper()
+	lang, _, err := detectLanguage(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reg := model.NewRegistry()
+	extractJS(parseTree(t, lang, src), []byte(src), file, reg)
+	return reg
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"path/filepath"
 	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
@@ -11,17 +12,59 @@ import (
 // extractJS walks the tree-sitter AST and extracts JS/TS symbols.
 func extractJS(root *sitter.Node, src []byte, file string, reg *model.Registry) {
 	ctx := &jsContext{
-		src:  src,
-		file: file,
-		reg:  reg,
+		src:    src,
+		file:   file,
+		module: jsModule(file),
+		reg:    reg,
 	}
+	ctx.propTypes = collectPropTypes(root, src)
 	ctx.processChildren(root, nil)
+	ctx.resolveWrappedComponents()
 }
 
 type jsContext struct {
-	src  []byte
-	file string
-	reg  *model.Registry
+	src    []byte
+	file   string
+	module string // Module identity used to qualify symbol IDs, see jsModule
+	reg    *model.Registry
+
+	classes   map[string]*model.Symbol // Classes declared in this file, by ID
+	propTypes map[string]*sitter.Node  // Interfaces and type aliases, by name, see collectPropTypes
+	wrapped   []wrappedComponent       // forwardRef( Name ) components awaiting Name's props
+}
+
+// jsModule derives a module identity from a JS/TS file path. Gutenberg
+// package sources (packages/<name>/src/...) and the built
+// wp-includes/js/dist/<name>.js bundles map to their @wordpress/<name>
+// package, with the path inside the package appended for sources; other
+// files use their path without the extension. index files are named after
+// their directory, as they are imported.
+func jsModule(file string) string {
+	path := filepath.ToSlash(file)
+	path = strings.TrimSuffix(path, filepath.Ext(path))
+	path = strings.TrimSuffix(path, ".min")
+
+	if rest, ok := strings.CutPrefix(path, "wp-includes/js/dist/"); ok && !strings.Contains(rest, "/") {
+		return "@wordpress/" + rest
+	}
+	if i := strings.Index(path, "packages/"); i == 0 || (i > 0 && path[i-1] == '/') {
+		pkg, inner, _ := strings.Cut(path[i+len("packages/"):], "/")
+		for _, dir := range []string{"src/", "build-module/", "build/"} {
+			inner = strings.TrimPrefix(inner, dir)
+		}
+		module := "@wordpress/" + pkg
+		if inner = strings.TrimSuffix(inner, "/index"); inner != "" && inner != "index" {
+			module += "/" + inner
+		}
+		return module
+	}
+	return strings.TrimSuffix(path, "/index")
+}
+
+// id qualifies a top-level symbol name with the file's module, so that
+// same-named functions in different packages get distinct IDs.
+func (ctx *jsContext) id(name string) string {
+	return ctx.module + "." + name
 }
 
 func (ctx *jsContext) processChildren(node *sitter.Node, classStack []string) {
@@ -35,15 +78,24 @@ func (ctx *jsContext) processNode(node *sitter.Node, classStack []string) {
 	switch node.Type() {
 	case "function_declaration":
 		ctx.handleFunction(node)
-	case "class_declaration":
+	case "class_declaration", "abstract_class_declaration":
 		ctx.handleClass(node, classStack)
 	case "interface_declaration":
-		ctx.handleInterface(node)
+		ctx.handleInterface(node, classStack)
+	case "type_alias_declaration":
+		ctx.handleTypeAlias(node, classStack)
+	case "enum_declaration":
+		ctx.handleEnum(node)
+	case "comment":
+		ctx.handleTypedefComment(node)
 	case "export_statement":
 		// Recurse into exported declarations
 		ctx.processChildren(node, classStack)
 	case "lexical_declaration", "variable_declaration":
 		ctx.handleVarDecl(node)
+	default:
+		// Module-scope code, e.g. addFilter( 'blocks.registerBlockType', ... )
+		ctx.scanHooks(node, nil)
 	}
 }
 
@@ -57,19 +109,25 @@ func (ctx *jsContext) handleFunction(node *sitter.Node) {
 	doc := findDocComment(node, ctx.src)
 
 	sym := &model.Symbol{
-		ID:       name,
-		Name:     name,
-		Kind:     model.KindFunction,
-		Language: "js",
-		Doc:      doc,
-		Params:   extractJSParams(node.ChildByFieldName("parameters"), ctx.src, doc),
-		Returns:  jsReturn(node, ctx.src, doc),
+		ID:        ctx.id(name),
+		Name:      name,
+		Kind:      model.KindFunction,
+		Language:  "js",
+		Namespace: ctx.module,
+		Doc:       doc,
+		Params:    extractJSParams(node.ChildByFieldName("parameters"), ctx.src, doc),
+		Returns:   jsReturn(node, ctx.src, doc),
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
 			EndLine:   endLine(node),
 		},
 	}
+	if isComponentName(name) && returnsJSX(node) {
+		sym.Kind = model.KindComponent
+		sym.Props, sym.PropsType = ctx.componentProps(node, doc)
+	}
+	ctx.scanHooks(node.ChildByFieldName("body"), sym)
 	ctx.reg.Add(sym)
 }
 
@@ -82,17 +140,21 @@ func (ctx *jsContext) handleClass(node *sitter.Node, classStack []string) {
 
 	doc := findDocComment(node, ctx.src)
 	sym := &model.Symbol{
-		ID:       name,
-		Name:     name,
-		Kind:     model.KindClass,
-		Language: "js",
-		Doc:      doc,
+		ID:        ctx.id(name),
+		Name:      name,
+		Kind:      model.KindClass,
+		Language:  "js",
+		Namespace: ctx.module,
+		Doc:       doc,
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
 			EndLine:   endLine(node),
 		},
 	}
+	if node.Type() == "abstract_class_declaration" {
+		sym.Modifiers = []string{"abstract"}
+	}
 
 	// Heritage: extends/implements
 	if heritage := childByType(node, "class_heritage"); heritage != nil {
@@ -101,29 +163,56 @@ func (ctx *jsContext) handleClass(node *sitter.Node, classStack []string) {
 			switch clause.Type() {
 			case "extends_clause":
 				for j := 0; j < int(clause.NamedChildCount()); j++ {
-					sym.Extends = append(sym.Extends, nodeText(clause.NamedChild(j), ctx.src))
+					// Skip the <Props> of `extends Component<Props>`
+					if base := clause.NamedChild(j); base.Type() != "type_arguments" {
+						sym.Extends = append(sym.Extends, nodeText(base, ctx.src))
+					}
 				}
 			case "implements_clause":
 				for j := 0; j < int(clause.NamedChildCount()); j++ {
 					sym.Implements = append(sym.Implements, nodeText(clause.NamedChild(j), ctx.src))
 				}
+			case "comment":
+			default:
+				// JavaScript has no extends_clause: `extends Base` is the heritage itself
+				sym.Extends = append(sym.Extends, nodeText(clause, ctx.src))
 			}
 		}
 	}
 
-	ctx.reg.Add(sym)
+	if ok, propsType := isComponentClass(node, ctx.src); ok {
+		var props propList
+		sym.Kind = model.KindComponent
+		if propsType != nil {
+			sym.PropsType = ctx.addTypeProps(&props, propsType)
+		}
+		props.addDocTags(doc)
+		sym.Props = props.params
+	}
+
+	ctx.addClass(sym)
 
 	// Process class body
 	if body := node.ChildByFieldName("body"); body != nil {
-		newStack := append(append([]string{}, classStack...), name)
+		newStack := append(append([]string{}, classStack...), sym.ID)
 		ctx.processClassBody(body, newStack)
 	}
 }
 
+// addClass registers a class-like symbol (class, interface, enum, type) and
+// remembers this declaration so its members are attached to it.
+func (ctx *jsContext) addClass(sym *model.Symbol) {
+	if ctx.classes == nil {
+		ctx.classes = make(map[string]*model.Symbol)
+	}
+	ctx.classes[sym.ID] = sym
+	ctx.reg.Add(sym)
+}
+
 func (ctx *jsContext) processClassBody(body *sitter.Node, classStack []string) {
 	for i := 0; i < int(body.NamedChildCount()); i++ {
 		child := body.NamedChild(i)
-		if child.Type() == "method_definition" {
+		if child.Type() == "method_definition" || child.Type() == "abstract_method_signature" {
 			ctx.handleMethod(child, classStack)
 		}
 	}
@@ -141,28 +230,33 @@ func (ctx *jsContext) handleMethod(node *sitter.Node, classStack []string) {
 
 	doc := findDocComment(node, ctx.src)
 	sym := &model.Symbol{
-		ID:       methodID,
-		Name:     name,
-		Kind:     model.KindMethod,
-		Language: "js",
-		Doc:      doc,
-		Params:   extractJSParams(node.ChildByFieldName("parameters"), ctx.src, doc),
-		Returns:  jsReturn(node, ctx.src, doc),
-		ParentID: classFQN,
+		ID:        methodID,
+		Name:      name,
+		Kind:      model.KindMethod,
+		Language:  "js",
+		Namespace: ctx.module,
+		Doc:       doc,
+		Params:    extractJSParams(node.ChildByFieldName("parameters"), ctx.src, doc),
+		Returns:   jsReturn(node, ctx.src, doc),
+		ParentID:  classFQN,
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
 			EndLine:   endLine(node),
 		},
 	}
+	if node.Type() == "abstract_method_signature" {
+		sym.Modifiers = []string{"abstract"}
+	}
+	ctx.scanHooks(node.ChildByFieldName("body"), sym)
 	ctx.reg.Add(sym)
 
-	if parent := ctx.reg.Get(classFQN); parent != nil {
+	if parent := ctx.classes[classFQN]; parent != nil {
 		parent.Members = append(parent.Members, methodID)
 	}
 }
 
-func (ctx *jsContext) handleInterface(node *sitter.Node) {
+func (ctx *jsContext) handleInterface(node *sitter.Node, classStack []string) {
 	nameNode := node.ChildByFieldName("name")
 	name := nodeText(nameNode, ctx.src)
 	if name == "" {
@@ -171,11 +265,12 @@ func (ctx *jsContext) handleInterface(node *sitter.Node) {
 
 	doc := findDocComment(node, ctx.src)
 	sym := &model.Symbol{
-		ID:       name,
-		Name:     name,
-		Kind:     model.KindInterface,
-		Language: "js",
-		Doc:      doc,
+		ID:        ctx.id(name),
+		Name:      name,
+		Kind:      model.KindInterface,
+		Language:  "js",
+		Namespace: ctx.module,
+		Doc:       doc,
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
@@ -190,7 +285,11 @@ func (ctx *jsContext) handleInterface(node *sitter.Node) {
 		}
 	}
 
-	ctx.reg.Add(sym)
+	ctx.addClass(sym)
+
+	if body := node.ChildByFieldName("body"); body != nil {
+		ctx.processTypeMembers(body, append(append([]string{}, classStack...), sym.ID))
+	}
 }
 
 func (ctx *jsContext) handleVarDecl(node *sitter.Node) {
@@ -209,6 +308,39 @@ func (ctx *jsContext) handleVarDecl(node *sitter.Node) {
 
 		// Check if the value is a function expression or arrow function
 		switch valueNode.Type() {
+		case "call_expression":
+			// const Button = forwardRef( UnforwardedButton )
+			fn, target, ok := unwrapComponent(valueNode, ctx.src)
+			name := nodeText(nameNode, ctx.src)
+			if !ok || name == "" {
+				// const settings = applyFilters( ... )
+				ctx.scanHooks(valueNode, nil)
+				continue
+			}
+			doc := findDocComment(node, ctx.src)
+			sym := &model.Symbol{
+				ID:        ctx.id(name),
+				Name:      name,
+				Kind:      model.KindComponent,
+				Language:  "js",
+				Namespace: ctx.module,
+				Doc:       doc,
+				Location: model.SourceLocation{
+					File:      ctx.file,
+					StartLine: startLine(node),
+					EndLine:   endLine(node),
+				},
+			}
+			if fn != nil {
+				sym.Params = extractJSParams(fn.ChildByFieldName("parameters"), ctx.src, doc)
+				sym.Returns = jsReturn(fn, ctx.src, doc)
+				sym.Props, sym.PropsType = ctx.componentProps(fn, doc)
+			} else {
+				ctx.wrapped = append(ctx.wrapped, wrappedComponent{sym: sym, target: target})
+			}
+			ctx.scanHooks(valueNode, sym)
+			ctx.reg.Add(sym)
+
 		case "arrow_function", "function_expression", "function":
 			name := nodeText(nameNode, ctx.src)
 			if name == "" {
@@ -219,20 +351,29 @@ func (ctx *jsContext) handleVarDecl(node *sitter.Node) {
 			doc := findDocComment(node, ctx.src)
 
 			sym := &model.Symbol{
-				ID:       name,
-				Name:     name,
-				Kind:     model.KindFunction,
-				Language: "js",
-				Doc:      doc,
-				Params:   extractJSParams(valueNode.ChildByFieldName("parameters"), ctx.src, doc),
-				Returns:  jsReturn(valueNode, ctx.src, doc),
+				ID:        ctx.id(name),
+				Name:      name,
+				Kind:      model.KindFunction,
+				Language:  "js",
+				Namespace: ctx.module,
+				Doc:       doc,
+				Params:    extractJSParams(valueNode.ChildByFieldName("parameters"), ctx.src, doc),
+				Returns:   jsReturn(valueNode, ctx.src, doc),
 				Location: model.SourceLocation{
 					File:      ctx.file,
 					StartLine: startLine(node),
 					EndLine:   endLine(node),
 				},
 			}
+			if isComponentName(name) && returnsJSX(valueNode) {
+				sym.Kind = model.KindComponent
+				sym.Props, sym.PropsType = ctx.componentProps(valueNode, doc)
+			}
+			ctx.scanHooks(valueNode.ChildByFieldName("body"), sym)
 			ctx.reg.Add(sym)
+
+		default:
+			ctx.scanHooks(valueNode, nil)
 		}
 	}
 }
@@ -256,10 +397,14 @@ func extractJSParams(paramsNode *sitter.Node, src []byte, doc model.DocBlock) []
 	for i := 0; i < int(paramsNode.NamedChildCount()); i++ {
 		param := paramsNode.NamedChild(i)
 
-		var name, typeName string
+		var name, typeName, defaultValue string
 		switch param.Type() {
 		case "identifier":
 			name = nodeText(param, src)
+		case "assignment_pattern":
+			// JS default: function f( a = 1 )
+			name = nodeText(param.ChildByFieldName("left"), src)
+			defaultValue = nodeText(param.ChildByFieldName("right"), src)
 		case "required_parameter", "optional_parameter":
 			if n := param.ChildByFieldName("pattern"); n != nil {
 				name = nodeText(n, src)
@@ -270,6 +415,8 @@ func extractJSParams(paramsNode *sitter.Node, src []byte, doc model.DocBlock) []
 				typeName = nodeText(t, src)
 				typeName = strings.TrimPrefix(typeName, ": ")
 			}
+			// TS default: function f( a: number = 1 )
+			defaultValue = nodeText(param.ChildByFieldName("value"), src)
 		case "formal_parameter":
 			if n := param.ChildByFieldName("name"); n != nil {
 				name = nodeText(n, src)
@@ -282,7 +429,7 @@ func extractJSParams(paramsNode *sitter.Node, src []byte, doc model.DocBlock) []
 			continue
 		}
 
-		mp := model.Param{Name: name, Type: typeName}
+		mp := model.Param{Name: name, Type: typeName, Default: defaultValue}
 
 		// Merge JSDoc info
 		if dp, ok := docMap[name]; ok {
@@ -290,6 +437,12 @@ func extractJSParams(paramsNode *sitter.Node, src []byte, doc model.DocBlock) []
 				mp.Type = dp.Type
 			}
 			mp.Description = dp.Description
+			if mp.Default == "" {
+				mp.Default = dp.Default
+			}
+			if mp.Default == "" {
+				mp.Default = ParseDocDefault(dp.Description)
+			}
 		}
 
 		result = append(result, mp)
@@ -310,12 +463,26 @@ func parseJSDocParam(raw string) model.Param {
 			raw = strings.TrimSpace(raw[endBrace+1:])
 		}
 	}
+	// Closure Compiler style optional type: {string=}
+	if t, ok := strings.CutSuffix(p.Type, "="); ok {
+		p.Type = strings.TrimSpace(t)
+		p.IsOptional = true
+	}
 
 	// Next is the name, then description
 	parts := strings.SplitN(raw, " ", 2)
 	if len(parts) >= 1 {
 		p.Name = strings.TrimPrefix(parts[0], "$")
 	}
+	// Optional parameter with default: [name=default]
+	if strings.HasPrefix(p.Name, "[") && strings.HasSuffix(p.Name, "]") {
+		p.Name = strings.Trim(p.Name, "[]")
+		p.IsOptional = true
+		if eq := strings.Index(p.Name, "="); eq != -1 {
+			p.Default = p.Name[eq+1:]
+			p.Name = p.Name[:eq]
+		}
+	}
 	if len(parts) >= 2 {
 		p.Description = strings.TrimSpace(parts[1])
 	}
@@ -334,6 +501,8 @@ func jsReturn(node *sitter.Node, src []byte, doc model.DocBlock) *model.ReturnVa
 
 	// Fall back to JSDoc @return
 	if ret := ParseReturn(doc); ret != nil {
+		// JSDoc wraps the type in braces: @return {boolean} ...
+		ret.Type = strings.TrimSuffix(strings.TrimPrefix(ret.Type, "{"), "}")
 		return ret
 	}
 
@@ -0,0 +1,368 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// componentWrappers are the React helpers whose result is a component:
+// forwardRef( ( props, ref ) => ... ) and memo( Component ).
+var componentWrappers = map[string]bool{
+	"forwardRef": true,
+	"memo":       true,
+}
+
+// componentBases are the classes a class component extends, as
+// Component, React.Component or wp.element.Component.
+var componentBases = map[string]bool{
+	"Component":     true,
+	"PureComponent": true,
+}
+
+// wrappedComponent is a component declared as forwardRef( Name ) or
+// memo( Name ), whose props are taken from the function Name once the
+// whole file has been processed.
+type wrappedComponent struct {
+	sym    *model.Symbol
+	target string
+}
+
+// isComponentName reports whether name can be a React component. JSX treats
+// lower-case tags as DOM elements, so components are always capitalised.
+func isComponentName(name string) bool {
+	for _, r := range name {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// returnsJSX reports whether a function returns JSX: an arrow function with
+// a JSX expression body, or a return statement with a JSX value anywhere in
+// the body outside nested functions.
+func returnsJSX(fn *sitter.Node) bool {
+	body := fn.ChildByFieldName("body")
+	if body == nil {
+		return false
+	}
+	if body.Type() != "statement_block" {
+		return isJSXValue(body)
+	}
+	found := false
+	var walk func(n *sitter.Node)
+	walk = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()) && !found; i++ {
+			child := n.NamedChild(i)
+			switch child.Type() {
+			case "function_declaration", "function_expression", "function", "arrow_function", "class_declaration", "class":
+				continue
+			case "return_statement":
+				if child.NamedChildCount() > 0 && isJSXValue(child.NamedChild(0)) {
+					found = true
+					return
+				}
+			}
+			walk(child)
+		}
+	}
+	walk(body)
+	return found
+}
+
+// isJSXValue reports whether an expression evaluates to JSX, looking through
+// parentheses, `cond ? <A /> : <B />` and `cond && <A />`.
+func isJSXValue(node *sitter.Node) bool {
+	switch node.Type() {
+	case "jsx_element", "jsx_self_closing_element", "jsx_fragment":
+		return true
+	case "parenthesized_expression":
+		return node.NamedChildCount() > 0 && isJSXValue(node.NamedChild(0))
+	case "ternary_expression":
+		for _, field := range []string{"consequence", "alternative"} {
+			if n := node.ChildByFieldName(field); n != nil && isJSXValue(n) {
+				return true
+			}
+		}
+	case "binary_expression":
+		if n := node.ChildByFieldName("right"); n != nil {
+			return isJSXValue(n)
+		}
+	}
+	return false
+}
+
+// unwrapComponent follows nested forwardRef()/memo() calls to the wrapped
+// component: a function node, or the name of a function declared elsewhere.
+// ok is false when call isn't such a wrapper.
+func unwrapComponent(call *sitter.Node, src []byte) (fn *sitter.Node, target string, ok bool) {
+	for call.Type() == "call_expression" {
+		callee := nodeText(call.ChildByFieldName("function"), src)
+		if !componentWrappers[callee[strings.LastIndex(callee, ".")+1:]] {
+			return nil, "", false
+		}
+		args := call.ChildByFieldName("arguments")
+		if args == nil || args.NamedChildCount() == 0 {
+			return nil, "", false
+		}
+		call = args.NamedChild(0)
+	}
+	switch call.Type() {
+	case "arrow_function", "function_expression", "function":
+		return call, "", true
+	case "identifier":
+		return nil, nodeText(call, src), true
+	}
+	return nil, "", false
+}
+
+// isComponentClass reports whether a class extends React's Component or
+// PureComponent, and returns the node of its props type argument, if any.
+func isComponentClass(node *sitter.Node, src []byte) (bool, *sitter.Node) {
+	heritage := childByType(node, "class_heritage")
+	if heritage == nil {
+		return false, nil
+	}
+	clause := childByType(heritage, "extends_clause")
+	if clause == nil {
+		clause = heritage // JavaScript: class_heritage holds the base expression itself
+	}
+	if clause.NamedChildCount() == 0 {
+		return false, nil
+	}
+	base := nodeText(clause.NamedChild(0), src)
+	if !componentBases[base[strings.LastIndex(base, ".")+1:]] {
+		return false, nil
+	}
+	// class Panel extends Component< PanelProps, PanelState >
+	if args := clause.ChildByFieldName("type_arguments"); args != nil && args.NamedChildCount() > 0 {
+		return true, args.NamedChild(0)
+	}
+	return true, nil
+}
+
+// collectPropTypes indexes the file's top-level interfaces and object type
+// aliases by name, so a component can find its props type wherever in the
+// file it is declared.
+func collectPropTypes(root *sitter.Node, src []byte) map[string]*sitter.Node {
+	types := make(map[string]*sitter.Node)
+	var visit func(n *sitter.Node)
+	visit = func(n *sitter.Node) {
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			child := n.NamedChild(i)
+			switch child.Type() {
+			case "export_statement":
+				visit(child)
+			case "interface_declaration":
+				types[nodeText(child.ChildByFieldName("name"), src)] = child.ChildByFieldName("body")
+			case "type_alias_declaration":
+				types[nodeText(child.ChildByFieldName("name"), src)] = child.ChildByFieldName("value")
+			}
+		}
+	}
+	visit(root)
+	return types
+}
+
+// componentProps builds the props of a function component from its first
+// parameter: names and defaults from a destructuring pattern, types and
+// descriptions from a TypeScript props type declared in the same file, and
+// JSDoc `@param {type} props.name` tags. It also returns the name of the
+// props type.
+func (ctx *jsContext) componentProps(fn *sitter.Node, doc model.DocBlock) ([]model.Param, string) {
+	var props propList
+	var propsType string
+
+	var first *sitter.Node
+	if params := fn.ChildByFieldName("parameters"); params != nil && params.NamedChildCount() > 0 {
+		first = params.NamedChild(0)
+	} else if param := fn.ChildByFieldName("parameter"); param != nil {
+		first = param // props => ...
+	}
+	if first != nil {
+		pattern := first
+		if p := first.ChildByFieldName("pattern"); p != nil {
+			pattern = p
+		}
+		if pattern.Type() == "assignment_pattern" {
+			// function Foo( { a } = {} )
+			pattern = pattern.ChildByFieldName("left")
+		}
+		if pattern != nil && pattern.Type() == "object_pattern" {
+			props.addPattern(pattern, ctx.src)
+		}
+		if t := first.ChildByFieldName("type"); t != nil {
+			propsType = ctx.addTypeProps(&props, t)
+		}
+	}
+
+	props.addDocTags(doc)
+
+	// A prop with a default can always be left out, whatever its type says.
+	for i := range props.params {
+		if props.params[i].IsOptional {
+			props.params[i].IsRequired = false
+		}
+	}
+	return props.params, propsType
+}
+
+// addTypeProps adds the properties of a TypeScript props type and returns
+// the name of the type. Named types are looked up among the file's
+// interfaces and type aliases; WordPressComponentProps< Props, 'div' > and
+// similar generic wrappers contribute their first type argument, and
+// intersections contribute every member.
+func (ctx *jsContext) addTypeProps(props *propList, node *sitter.Node) string {
+	switch node.Type() {
+	case "type_annotation", "parenthesized_type":
+		if node.NamedChildCount() > 0 {
+			return ctx.addTypeProps(props, node.NamedChild(0))
+		}
+	case "type_identifier":
+		name := nodeText(node, ctx.src)
+		if body := ctx.propTypes[name]; body != nil {
+			ctx.addTypeProps(props, body)
+		}
+		return name
+	case "generic_type":
+		name := nodeText(node.ChildByFieldName("name"), ctx.src)
+		if body := ctx.propTypes[name]; body != nil {
+			ctx.addTypeProps(props, body)
+			return name
+		}
+		if args := childByType(node, "type_arguments"); args != nil && args.NamedChildCount() > 0 {
+			return ctx.addTypeProps(props, args.NamedChild(0))
+		}
+		return name
+	case "intersection_type":
+		var names []string
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			if name := ctx.addTypeProps(props, node.NamedChild(i)); name != "" {
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, " & ")
+	case "object_type", "interface_body":
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			sig := node.NamedChild(i)
+			if sig.Type() != "property_signature" {
+				continue
+			}
+			p := props.get(nodeText(sig.ChildByFieldName("name"), ctx.src))
+			if p.Type == "" {
+				p.Type = strings.TrimSpace(strings.TrimPrefix(nodeText(sig.ChildByFieldName("type"), ctx.src), ":"))
+			}
+			if p.Description == "" {
+				p.Description = findDocComment(sig, ctx.src).Summary
+			}
+			optional := false
+			for j := 0; j < int(sig.ChildCount()); j++ {
+				if sig.Child(j).Type() == "?" {
+					optional = true
+				}
+			}
+			p.IsOptional = p.IsOptional || optional
+			p.IsRequired = p.IsRequired || !optional
+		}
+	}
+	return ""
+}
+
+// propList collects props by name, in the order they're first seen.
+type propList struct {
+	params []model.Param
+}
+
+func (l *propList) get(name string) *model.Param {
+	for i := range l.params {
+		if l.params[i].Name == name {
+			return &l.params[i]
+		}
+	}
+	l.params = append(l.params, model.Param{Name: name})
+	return &l.params[len(l.params)-1]
+}
+
+// addPattern adds the props named in a destructuring pattern such as
+// { label, size = 'default', onClick: handleClick }. A ...rest element
+// collects whatever else is passed and isn't a prop itself.
+func (l *propList) addPattern(pattern *sitter.Node, src []byte) {
+	for i := 0; i < int(pattern.NamedChildCount()); i++ {
+		elem := pattern.NamedChild(i)
+		switch elem.Type() {
+		case "shorthand_property_identifier_pattern":
+			l.get(nodeText(elem, src))
+		case "object_assignment_pattern":
+			p := l.get(nodeText(elem.ChildByFieldName("left"), src))
+			p.Default = nodeText(elem.ChildByFieldName("right"), src)
+			p.IsOptional = true
+		case "pair_pattern":
+			p := l.get(unquoteJS(nodeText(elem.ChildByFieldName("key"), src)))
+			if value := elem.ChildByFieldName("value"); value != nil && value.Type() == "assignment_pattern" {
+				p.Default = nodeText(value.ChildByFieldName("right"), src)
+				p.IsOptional = true
+			}
+		}
+	}
+}
+
+// addDocTags adds the props documented as `@param {type} props.name` under
+// the component's first @param tag. Deeper paths (props.style.color) are
+// left to the prop's own description.
+func (l *propList) addDocTags(doc model.DocBlock) {
+	root := ""
+	for _, raw := range doc.Tags["param"] {
+		dp := parseJSDocParam(raw)
+		if root == "" {
+			if strings.Contains(dp.Name, ".") {
+				return
+			}
+			root = dp.Name
+			continue
+		}
+		name, ok := strings.CutPrefix(dp.Name, root+".")
+		if !ok || name == "" || strings.Contains(name, ".") {
+			continue
+		}
+		p := l.get(name)
+		if p.Type == "" {
+			p.Type = dp.Type
+		}
+		if p.Description == "" {
+			p.Description = dp.Description
+		}
+		if p.Default == "" {
+			p.Default = dp.Default
+		}
+		p.IsOptional = p.IsOptional || dp.IsOptional
+		p.IsRequired = p.IsRequired || !dp.IsOptional
+	}
+}
+
+// unquoteJS strips the quotes from a string property key.
+func unquoteJS(s string) string {
+	if len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+// resolveWrappedComponents gives each forwardRef( Name )/memo( Name )
+// component the params, props and docs of the function it wraps.
+func (ctx *jsContext) resolveWrappedComponents() {
+	for _, w := range ctx.wrapped {
+		target := ctx.reg.Get(ctx.id(w.target))
+		if target == nil || target.Location.File != ctx.file {
+			continue
+		}
+		w.sym.Params = target.Params
+		w.sym.Returns = target.Returns
+		w.sym.Props = target.Props
+		w.sym.PropsType = target.PropsType
+		if w.sym.Doc.Summary == "" && w.sym.Doc.Description == "" {
+			w.sym.Doc = target.Doc
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestComponentKinds(t *testing.T) {
+	src := `export function Button( { label } ) {
+	return <button>{ label }</button>;
+}
+
+function render() {
+	return <div />;
+}
+
+const Icon = ( { icon } ) => ( icon ? <span /> : null );
+
+function Factory() {
+	return () => <div />;
+}
+
+class Panel extends Component {
+	render() {
+		return <div />;
+	}
+}
+
+class Store extends Base {}
+
+const Memoized = memo( () => <div /> );
+
+const Forwarded = forwardRef( Button );
+
+const settings = applyFilters( 'settings', {} );
+`
+	reg := extractJSSource(t, "components.js", src)
+
+	tests := []struct {
+		name string
+		want model.SymbolKind
+	}{
+		{"Button", model.KindComponent},
+		{"render", model.KindFunction},
+		{"Icon", model.KindComponent},
+		{"Factory", model.KindFunction}, // Returns a function, not JSX
+		{"Panel", model.KindComponent},
+		{"Store", model.KindClass},
+		{"Memoized", model.KindComponent},
+		{"Forwarded", model.KindComponent},
+	}
+	for _, tt := range tests {
+		sym := reg.Get("components." + tt.name)
+		if sym == nil {
+			t.Errorf("%s not extracted", tt.name)
+			continue
+		}
+		if sym.Kind != tt.want {
+			t.Errorf("%s is a %s, want %s", tt.name, sym.Kind, tt.want)
+		}
+	}
+	if sym := reg.Get("components.settings"); sym != nil {
+		t.Errorf("settings extracted as a %s", sym.Kind)
+	}
+	if forwarded, button := reg.Get("components.Forwarded"), reg.Get("components.Button"); forwarded != nil && button != nil {
+		if !reflect.DeepEqual(forwarded.Props, button.Props) {
+			t.Errorf("Forwarded props = %+v, want Button's %+v", forwarded.Props, button.Props)
+		}
+	}
+}
+
+func TestComponentProps(t *testing.T) {
+	tests := []struct {
+		name      string
+		file      string
+		src       string
+		want      []model.Param
+		propsType string
+	}{
+		{
+			name: "TypeScript props type",
+			file: "button.tsx",
+			src: `interface ButtonProps {
+	/** The button label. */
+	label: string;
+	/** The button size. */
+	size?: 'small' | 'default';
+	onClick: () => void;
+}
+
+export function Button( { label, size = 'default', onClick: handleClick }: ButtonProps ) {
+	return <button onClick={ handleClick }>{ label }</button>;
+}
+`,
+			want: []model.Param{
+				{Name: "label", Type: "string", Description: "The button label.", IsRequired: true},
+				{Name: "size", Type: "'small' | 'default'", Description: "The button size.", Default: "'default'", IsOptional: true},
+				{Name: "onClick", Type: "() => void", IsRequired: true},
+			},
+			propsType: "ButtonProps",
+		},
+		{
+			name: "defaults override required types",
+			file: "toggle.tsx",
+			src: `type ToggleProps = { checked: boolean; help: string };
+
+export const Toggle = ( { checked = false, help }: ToggleProps ) => <input checked={ checked } />;
+`,
+			want: []model.Param{
+				{Name: "checked", Type: "boolean", Default: "false", IsOptional: true},
+				{Name: "help", Type: "string", IsRequired: true},
+			},
+			propsType: "ToggleProps",
+		},
+		{
+			name: "generic wrapper and intersection",
+			file: "card.tsx",
+			src: `type Own = { title: string };
+type Extra = { footer?: string };
+
+export function Card( props: WordPressComponentProps< Own & Extra, 'div' > ) {
+	return <div />;
+}
+`,
+			want: []model.Param{
+				{Name: "title", Type: "string", IsRequired: true},
+				{Name: "footer", Type: "string", IsOptional: true},
+			},
+			propsType: "Own & Extra",
+		},
+		{
+			name: "JSDoc props",
+			file: "notice.js",
+			src: `/**
+ * Shows a notice.
+ *
+ * @param {Object}   props          Component props.
+ * @param {string}   props.status   Notice status.
+ * @param {boolean}  [props.isDismissible=true] Whether it can be dismissed.
+ * @param {Object}   props.style.color Ignored, too deep.
+ */
+export function Notice( { status, isDismissible, children } ) {
+	return <div>{ children }</div>;
+}
+`,
+			want: []model.Param{
+				{Name: "status", Type: "string", Description: "Notice status.", IsRequired: true},
+				{Name: "isDismissible", Type: "boolean", Description: "Whether it can be dismissed.", Default: "true", IsOptional: true},
+				{Name: "children"},
+			},
+		},
+		{
+			name: "undocumented props are neither required nor optional",
+			file: "plain.js",
+			src:  `export const Plain = ( { a, b = 1 } ) => <i />;`,
+			want: []model.Param{
+				{Name: "a"},
+				{Name: "b", Default: "1", IsOptional: true},
+			},
+		},
+		{
+			name: "class component",
+			file: "panel.tsx",
+			src: `interface PanelProps {
+	title?: string;
+}
+
+export class Panel extends Component< PanelProps > {
+	render() {
+		return <div />;
+	}
+}
+`,
+			want:      []model.Param{{Name: "title", Type: "string", IsOptional: true}},
+			propsType: "PanelProps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := extractJSSource(t, tt.file, tt.src)
+			var sym *model.Symbol
+			for _, s := range reg.ByKind(model.KindComponent) {
+				sym = s
+			}
+			if sym == nil {
+				t.Fatal("no component extracted")
+			}
+			if !reflect.DeepEqual(sym.Props, tt.want) {
+				t.Errorf("Props =\n%+v\nwant\n%+v", sym.Props, tt.want)
+			}
+			if sym.PropsType != tt.propsType {
+				t.Errorf("PropsType = %q, want %q", sym.PropsType, tt.propsType)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package parser
+
+import (
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// @wordpress/hooks functions that fire a hook, called directly after
+// importing them or through the wp.hooks global.
+var jsHookFunctions = map[string]model.HookType{
+	"doAction":          model.HookAction,
+	"doActionAsync":     model.HookAction,
+	"applyFilters":      model.HookFilter,
+	"applyFiltersAsync": model.HookFilter,
+}
+
+// @wordpress/hooks functions that bind callbacks to a hook or inspect it.
+// Unlike PHP's add_action(), the second argument of addAction(), addFilter(),
+// removeAction() and removeFilter() is a namespace identifying the callback.
+var jsHookRegistrationFunctions = map[string]bool{
+	"addAction":    true,
+	"addFilter":    true,
+	"removeAction": true,
+	"removeFilter": true,
+	"hasAction":    true,
+	"hasFilter":    true,
+	"didAction":    true,
+	"didFilter":    true,
+}
+
+// jsHookID returns the symbol ID of a JavaScript hook. JS hooks live apart
+// from PHP hooks, which may share their names.
+func jsHookID(tag string) string {
+	return "jshook:" + tag
+}
+
+// scanHooks walks a subtree looking for @wordpress/hooks calls. caller is the
+// enclosing declaration, or nil for module scope; it is scanned before being
+// added to the registry, so hooks it binds are recorded on this declaration
+// rather than on whichever declaration of its ID the registry holds.
+func (ctx *jsContext) scanHooks(node *sitter.Node, caller *model.Symbol) {
+	if node == nil {
+		return
+	}
+	callerID := ""
+	if caller != nil {
+		callerID = caller.ID
+	}
+	walkTree(node, func(n *sitter.Node) {
+		if n.Type() != "call_expression" {
+			return
+		}
+		fnName := nodeText(n.ChildByFieldName("function"), ctx.src)
+		fnName = fnName[strings.LastIndex(fnName, ".")+1:]
+		if hookType, ok := jsHookFunctions[fnName]; ok {
+			ctx.registerHook(n, fnName, hookType, callerID)
+		} else if jsHookRegistrationFunctions[fnName] {
+			ctx.registerHookCallback(n, fnName, caller)
+		}
+	})
+}
+
+// registerHook records a JS hook firing as a call site on the hook symbol,
+// creating the symbol on first sight.
+func (ctx *jsContext) registerHook(call *sitter.Node, fnName string, hookType model.HookType, callerID string) {
+	args := jsCallArguments(call)
+	if len(args) == 0 {
+		return
+	}
+	tag := extractJSHookTag(args[0], ctx.src)
+	if tag == "" {
+		return
+	}
+
+	site := model.CallSite{
+		CallerID: callerID,
+		File:     ctx.file,
+		Line:     startLine(call),
+		Function: fnName,
+		Doc:      jsHookDocComment(call, ctx.src),
+	}
+	site.DocumentedIn = duplicateHookFile(site.Doc)
+	for _, arg := range args[1:] {
+		site.Args = append(site.Args, nodeText(arg, ctx.src))
+	}
+
+	sym := &model.Symbol{
+		ID:        jsHookID(tag),
+		Name:      tag,
+		Kind:      model.KindHook,
+		Language:  "js",
+		HookTag:   tag,
+		IsDynamic: isDynamicHookTag(tag),
+		CallSites: []model.CallSite{site},
+	}
+	applyJSHookDoc(sym, site, hookType, call)
+
+	// As with PHP hooks, keep every call site and take the documentation
+	// from the best one.
+	ctx.reg.AddOrMerge(sym, func(existing *model.Symbol) {
+		existing.CallSites = append(existing.CallSites, site)
+		if betterHookDoc(site, existing) {
+			applyJSHookDoc(existing, site, hookType, call)
+		}
+	})
+}
+
+// applyJSHookDoc makes site the documented call site of a JS hook symbol.
+func applyJSHookDoc(sym *model.Symbol, site model.CallSite, hookType model.HookType, call *sitter.Node) {
+	applyHookDoc(sym, site, hookType, call)
+	sym.Params = extractJSDocParams(site.Doc)
+}
+
+// jsHookDocComment finds the JSDoc block for a hook call, which sits before
+// the enclosing statement or declaration: `settings = applyFilters( ... )`,
+// `const x = applyFilters( ... )`, `return applyFilters( ... )`.
+func jsHookDocComment(call *sitter.Node, src []byte) model.DocBlock {
+	node := call
+	for parent := call.Parent(); parent != nil; parent = parent.Parent() {
+		t := parent.Type()
+		if t == "statement_block" || t == "program" || t == "class_body" {
+			break
+		}
+		node = parent
+		if strings.HasSuffix(t, "_statement") || strings.HasSuffix(t, "_declaration") {
+			break
+		}
+	}
+	return findDocComment(node, src)
+}
+
+// registerHookCallback records an addAction/addFilter style call against its
+// hook tag and links the enclosing declaration to the hook via Uses.
+func (ctx *jsContext) registerHookCallback(call *sitter.Node, fnName string, caller *model.Symbol) {
+	args := jsCallArguments(call)
+	if len(args) == 0 {
+		return
+	}
+	tag := extractJSHookTag(args[0], ctx.src)
+	if tag == "" {
+		return
+	}
+
+	cb := model.HookCallback{
+		Tag:      tag,
+		Function: fnName,
+		Language: "js",
+		File:     ctx.file,
+		Line:     startLine(call),
+	}
+	switch fnName {
+	case "addAction", "addFilter":
+		// addFilter( hookName, namespace, callback, priority = 10 )
+		cb.Priority = "10"
+		if len(args) > 1 {
+			cb.Namespace = extractJSHookTag(args[1], ctx.src)
+		}
+		if len(args) > 2 {
+			cb.Callback = jsCallbackName(args[2], ctx.src)
+		}
+		if len(args) > 3 {
+			cb.Priority = nodeText(args[3], ctx.src)
+		}
+	case "removeAction", "removeFilter", "hasAction", "hasFilter":
+		if len(args) > 1 {
+			cb.Namespace = extractJSHookTag(args[1], ctx.src)
+		}
+	}
+	if caller != nil {
+		cb.CallerID = caller.ID
+		caller.Uses = appendUnique(caller.Uses, jsHookID(tag))
+	}
+	ctx.reg.AddHookCallback(cb)
+}
+
+// jsCallbackName renders a JS callback expression: identifiers and member
+// expressions as written, inline functions as "{closure}".
+func jsCallbackName(node *sitter.Node, src []byte) string {
+	switch node.Type() {
+	case "arrow_function", "function_expression", "function":
+		return "{closure}"
+	}
+	return nodeText(node, src)
+}
+
+// jsCallArguments returns the arguments of a JS call, skipping comments.
+func jsCallArguments(call *sitter.Node) []*sitter.Node {
+	var result []*sitter.Node
+	for _, arg := range callArguments(call) {
+		if arg.Type() != "comment" {
+			result = append(result, arg)
+		}
+	}
+	return result
+}
+
+// extractJSHookTag resolves a JS hook name. Dynamic parts of template
+// literals (`blocks.${ name }.edit`) and concatenations ('blocks.' + name)
+// become {expression} placeholders, as with PHP hooks.
+func extractJSHookTag(node *sitter.Node, src []byte) string {
+	if node == nil {
+		return ""
+	}
+	switch node.Type() {
+	case "string":
+		return unquoteJS(nodeText(node, src))
+
+	case "template_string":
+		var b strings.Builder
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			child := node.NamedChild(i)
+			switch child.Type() {
+			case "string_fragment", "escape_sequence":
+				b.WriteString(nodeText(child, src))
+			case "template_substitution":
+				if child.NamedChildCount() > 0 {
+					b.WriteString(hookPlaceholder(child.NamedChild(0), src))
+				}
+			}
+		}
+		if b.Len() == 0 {
+			return strings.Trim(nodeText(node, src), "`")
+		}
+		return b.String()
+
+	case "binary_expression":
+		left := node.ChildByFieldName("left")
+		right := node.ChildByFieldName("right")
+		leftStr := extractJSHookTag(left, src)
+		rightStr := extractJSHookTag(right, src)
+		if leftStr != "" || rightStr != "" {
+			if leftStr == "" {
+				leftStr = hookPlaceholder(left, src)
+			}
+			if rightStr == "" {
+				rightStr = hookPlaceholder(right, src)
+			}
+			return leftStr + rightStr
+		}
+	}
+	return ""
+}
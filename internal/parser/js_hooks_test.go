@@ -0,0 +1,135 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestJSHookTags(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "string tags",
+			src:  `doAction( 'editor.init' ); wp.hooks.applyFilters( "blocks.registerBlockType", settings );`,
+			want: []string{"editor.init", "blocks.registerBlockType"},
+		},
+		{
+			name: "template literals",
+			src:  "applyFilters( `blocks.${ name }.edit`, edit ); doAction( `plain.tag` );",
+			want: []string{"blocks.{name}.edit", "plain.tag"},
+		},
+		{
+			name: "concatenation",
+			src:  `applyFilters( 'editor.' + hookName, value ); doActionAsync( prefix + '.done' );`,
+			want: []string{"editor.{hookName}", "{prefix}.done"},
+		},
+		{
+			name: "dynamic tags only",
+			src:  `doAction( hookName ); applyFilters();`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := extractJSSource(t, "hooks.js", tt.src)
+			var got []string
+			for _, sym := range reg.ByKind(model.KindHook) {
+				got = append(got, sym.HookTag)
+				if want := jsHookID(sym.HookTag); sym.ID != want || sym.Language != "js" {
+					t.Errorf("hook %q has ID %q and language %q, want %q and js", sym.HookTag, sym.ID, sym.Language, want)
+				}
+				if sym.IsDynamic != isDynamicHookTag(sym.HookTag) {
+					t.Errorf("hook %q IsDynamic = %v", sym.HookTag, sym.IsDynamic)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("hooks = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSHookDoc(t *testing.T) {
+	src := `export function registerBlockType( name, settings ) {
+	/**
+	 * Filters the settings of a block.
+	 *
+	 * @param {Object} settings Block settings.
+	 * @param {string} name     Block name.
+	 */
+	settings = applyFilters( 'blocks.registerBlockType', settings, name );
+
+	const edit = applyFilters( 'blocks.registerBlockType', settings, name );
+	return edit;
+}
+`
+	reg := extractJSSource(t, "packages/blocks/src/api/registration.js", src)
+	hook := reg.Get("jshook:blocks.registerBlockType")
+	if hook == nil {
+		t.Fatal("hook not extracted")
+	}
+	if hook.Doc.Summary != "Filters the settings of a block." {
+		t.Errorf("Summary = %q", hook.Doc.Summary)
+	}
+	if hook.HookType != model.HookFilter {
+		t.Errorf("HookType = %q, want filter", hook.HookType)
+	}
+	want := []model.Param{
+		{Name: "settings", Type: "Object", Description: "Block settings."},
+		{Name: "name", Type: "string", Description: "Block name."},
+	}
+	if !reflect.DeepEqual(hook.Params, want) {
+		t.Errorf("Params = %+v, want %+v", hook.Params, want)
+	}
+
+	caller := "@wordpress/blocks/api/registration.registerBlockType"
+	if len(hook.CallSites) != 2 {
+		t.Fatalf("got %d call sites, want 2", len(hook.CallSites))
+	}
+	for i, site := range hook.CallSites {
+		if site.CallerID != caller || site.Function != "applyFilters" || site.Line != 8+2*i {
+			t.Errorf("call site %d = %+v", i, site)
+		}
+		if want := []string{"settings", "name"}; !reflect.DeepEqual(site.Args, want) {
+			t.Errorf("call site %d args = %q, want %q", i, site.Args, want)
+		}
+	}
+}
+
+func TestJSHookCallbacks(t *testing.T) {
+	src := `import { addFilter, removeAction } from '@wordpress/hooks';
+
+function withInspector( BlockEdit ) {
+	return BlockEdit;
+}
+
+export function setup() {
+	addFilter( 'editor.BlockEdit', 'my-plugin/with-inspector', withInspector );
+	wp.hooks.addAction( 'editor.init', 'my-plugin/init', () => {}, 20 );
+	removeAction( 'editor.init', 'core/init' );
+}
+`
+	reg := extractJSSource(t, "plugin.js", src)
+	setup := "plugin.setup"
+	want := []model.HookCallback{
+		{Tag: "editor.BlockEdit", Function: "addFilter", Callback: "withInspector", Priority: "10", CallerID: setup, Language: "js", Namespace: "my-plugin/with-inspector", File: "plugin.js", Line: 8},
+		{Tag: "editor.init", Function: "addAction", Callback: "{closure}", Priority: "20", CallerID: setup, Language: "js", Namespace: "my-plugin/init", File: "plugin.js", Line: 9},
+		{Tag: "editor.init", Function: "removeAction", CallerID: setup, Language: "js", Namespace: "core/init", File: "plugin.js", Line: 10},
+	}
+	if got := reg.HookCallbacks(); !reflect.DeepEqual(got, want) {
+		t.Errorf("HookCallbacks() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	sym := reg.Get(setup)
+	if sym == nil {
+		t.Fatalf("%s not extracted", setup)
+	}
+	if want := []string{"jshook:editor.BlockEdit", "jshook:editor.init"}; !reflect.DeepEqual(sym.Uses, want) {
+		t.Errorf("Uses = %q, want %q", sym.Uses, want)
+	}
+}
@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/smacker/go-tree-sitter/javascript"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestJSModule(t *testing.T) {
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"packages/blocks/src/api/registration.js", "@wordpress/blocks/api/registration"},
+		{"packages/blocks/src/index.js", "@wordpress/blocks"},
+		{"packages/components/src/button/index.tsx", "@wordpress/components/button"},
+		{"packages/hooks/build-module/createHooks.js", "@wordpress/hooks/createHooks"},
+		{"gutenberg/packages/data/src/registry.js", "@wordpress/data/registry"},
+		{"wp-includes/js/dist/block-editor.js", "@wordpress/block-editor"},
+		{"wp-includes/js/dist/block-editor.min.js", "@wordpress/block-editor"},
+		{"wp-includes/js/dist/vendor/react.js", "wp-includes/js/dist/vendor/react"},
+		{"wp-admin/js/post.js", "wp-admin/js/post"},
+		{"wp-includes/js/media/index.js", "wp-includes/js/media"},
+		{"mypackages/foo/src/a.js", "mypackages/foo/src/a"},
+	}
+	for _, tt := range tests {
+		if got := jsModule(tt.file); got != tt.want {
+			t.Errorf("jsModule(%q) = %q, want %q", tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestJSModuleIDs(t *testing.T) {
+	reg := model.NewRegistry()
+	src := `export function normalize( value ) { return value; }`
+	for _, file := range []string{"packages/blocks/src/api/utils.js", "packages/editor/src/utils/index.js"} {
+		extractJS(parseTree(t, javascript.GetLanguage(), src), []byte(src), file, reg)
+	}
+
+	tests := []struct {
+		id, namespace string
+	}{
+		{"@wordpress/blocks/api/utils.normalize", "@wordpress/blocks/api/utils"},
+		{"@wordpress/editor/utils.normalize", "@wordpress/editor/utils"},
+	}
+	for _, tt := range tests {
+		sym := reg.Get(tt.id)
+		if sym == nil {
+			t.Errorf("%s not extracted", tt.id)
+			continue
+		}
+		if sym.Namespace != tt.namespace {
+			t.Errorf("%s Namespace = %q, want %q", tt.id, sym.Namespace, tt.namespace)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// handleTypeAlias registers a TypeScript `type Name = ...` declaration. The
+// properties of an object type are registered as members; any other type is
+// kept as the alias's Type.
+func (ctx *jsContext) handleTypeAlias(node *sitter.Node, classStack []string) {
+	name := nodeText(node.ChildByFieldName("name"), ctx.src)
+	if name == "" {
+		return
+	}
+
+	sym := &model.Symbol{
+		ID:        ctx.id(name),
+		Name:      name,
+		Kind:      model.KindTypeAlias,
+		Language:  "js",
+		Namespace: ctx.module,
+		Doc:       findDocComment(node, ctx.src),
+		Location: model.SourceLocation{
+			File:      ctx.file,
+			StartLine: startLine(node),
+			EndLine:   endLine(node),
+		},
+	}
+	value := node.ChildByFieldName("value")
+	if value != nil && value.Type() != "object_type" {
+		sym.Type = nodeText(value, ctx.src)
+	}
+	ctx.addClass(sym)
+
+	if value != nil && value.Type() == "object_type" {
+		ctx.processTypeMembers(value, append(append([]string{}, classStack...), sym.ID))
+	}
+}
+
+// processTypeMembers registers the property and method signatures of an
+// interface body or object type as members of the innermost class.
+func (ctx *jsContext) processTypeMembers(body *sitter.Node, classStack []string) {
+	for i := 0; i < int(body.NamedChildCount()); i++ {
+		child := body.NamedChild(i)
+		switch child.Type() {
+		case "property_signature":
+			ctx.handlePropertySignature(child, classStack)
+		case "method_signature":
+			ctx.handleMethod(child, classStack)
+		}
+	}
+}
+
+// handlePropertySignature registers an interface or object type property
+// such as `readonly name?: string`.
+func (ctx *jsContext) handlePropertySignature(node *sitter.Node, classStack []string) {
+	name := nodeText(node.ChildByFieldName("name"), ctx.src)
+	if name == "" || len(classStack) == 0 {
+		return
+	}
+	parentID := classStack[len(classStack)-1]
+
+	var modifiers []string
+	for i := 0; i < int(node.ChildCount()); i++ {
+		switch t := node.Child(i).Type(); t {
+		case "readonly":
+			modifiers = append(modifiers, t)
+		case "?":
+			modifiers = append(modifiers, "optional")
+		}
+	}
+	ctx.addProperty(parentID, &model.Symbol{
+		Name:      name,
+		Doc:       findDocComment(node, ctx.src),
+		Type:      strings.TrimSpace(strings.TrimPrefix(nodeText(node.ChildByFieldName("type"), ctx.src), ":")),
+		Modifiers: modifiers,
+		Location: model.SourceLocation{
+			File:      ctx.file,
+			StartLine: startLine(node),
+			EndLine:   endLine(node),
+		},
+	})
+}
+
+// addProperty registers a property of an interface, type alias or typedef
+// and lists it among the parent's members.
+func (ctx *jsContext) addProperty(parentID string, sym *model.Symbol) {
+	sym.ID = parentID + "." + sym.Name
+	sym.Kind = model.KindProperty
+	sym.Language = "js"
+	sym.Namespace = ctx.module
+	sym.ParentID = parentID
+	ctx.reg.Add(sym)
+
+	if parent := ctx.classes[parentID]; parent != nil {
+		parent.Members = append(parent.Members, sym.ID)
+	}
+}
+
+// handleEnum registers a TypeScript enum and its members as enum cases.
+func (ctx *jsContext) handleEnum(node *sitter.Node) {
+	name := nodeText(node.ChildByFieldName("name"), ctx.src)
+	if name == "" {
+		return
+	}
+
+	sym := &model.Symbol{
+		ID:        ctx.id(name),
+		Name:      name,
+		Kind:      model.KindEnum,
+		Language:  "js",
+		Namespace: ctx.module,
+		Doc:       findDocComment(node, ctx.src),
+		Location: model.SourceLocation{
+			File:      ctx.file,
+			StartLine: startLine(node),
+			EndLine:   endLine(node),
+		},
+	}
+	ctx.addClass(sym)
+
+	body := node.ChildByFieldName("body")
+	if body == nil {
+		return
+	}
+	for i := 0; i < int(body.NamedChildCount()); i++ {
+		member := body.NamedChild(i)
+		var caseName, value string
+		switch member.Type() {
+		case "property_identifier", "string":
+			caseName = unquoteJS(nodeText(member, ctx.src))
+		case "enum_assignment":
+			caseName = unquoteJS(nodeText(member.ChildByFieldName("name"), ctx.src))
+			value = nodeText(member.ChildByFieldName("value"), ctx.src)
+		default:
+			continue
+		}
+		caseID := sym.ID + "." + caseName
+		ctx.reg.Add(&model.Symbol{
+			ID:        caseID,
+			Name:      caseName,
+			Kind:      model.KindEnumCase,
+			Language:  "js",
+			Namespace: ctx.module,
+			Doc:       findDocComment(member, ctx.src),
+			Value:     value,
+			ParentID:  sym.ID,
+			Location: model.SourceLocation{
+				File:      ctx.file,
+				StartLine: startLine(member),
+				EndLine:   endLine(member),
+			},
+		})
+		sym.Members = append(sym.Members, caseID)
+	}
+}
+
+// typedefTagRegex matches a @typedef or @callback tag at the start of a
+// docblock line.
+var typedefTagRegex = regexp.MustCompile(`(?m)^[\s/*]*@(?:typedef|callback)\b`)
+
+// isTypedefBlock reports whether a docblock declares a type with @typedef or
+// @callback.
+func isTypedefBlock(text string) bool {
+	return typedefTagRegex.MatchString(text)
+}
+
+// handleTypedefComment registers the type a JSDoc block declares with
+// @typedef (an object shape, listing its @property tags as members) or
+// @callback (a function type, with @param and @return tags). These blocks
+// stand alone rather than documenting the code that follows them.
+func (ctx *jsContext) handleTypedefComment(node *sitter.Node) {
+	text := nodeText(node, ctx.src)
+	if !strings.HasPrefix(text, "/**") || !isTypedefBlock(text) {
+		return
+	}
+	doc := ParseDocBlock(text)
+
+	var typedef model.Param
+	if raw := doc.Tags["typedef"]; len(raw) > 0 {
+		typedef = parseJSDocParam(raw[0])
+	} else if raw := doc.Tags["callback"]; len(raw) > 0 {
+		typedef.Name = strings.TrimSpace(raw[0])
+	}
+	if typedef.Name == "" {
+		return
+	}
+
+	location := model.SourceLocation{
+		File:      ctx.file,
+		StartLine: startLine(node),
+		EndLine:   endLine(node),
+	}
+	sym := &model.Symbol{
+		ID:        ctx.id(typedef.Name),
+		Name:      typedef.Name,
+		Kind:      model.KindTypeAlias,
+		Language:  "js",
+		Namespace: ctx.module,
+		Doc:       doc,
+		Type:      typedef.Type,
+		Location:  location,
+	}
+	if _, ok := doc.Tags["callback"]; ok {
+		sym.Params = extractJSDocParams(doc)
+		sym.Returns = jsReturn(node, ctx.src, doc)
+	}
+	ctx.addClass(sym)
+
+	// Nested paths (settings.colors) describe a property's own shape and are
+	// left to its description.
+	for _, tag := range []string{"property", "prop"} {
+		for _, raw := range doc.Tags[tag] {
+			p := parseJSDocParam(raw)
+			if p.Name == "" || strings.Contains(p.Name, ".") {
+				continue
+			}
+			prop := &model.Symbol{
+				Name:     p.Name,
+				Doc:      model.DocBlock{Summary: p.Description},
+				Type:     p.Type,
+				Value:    p.Default,
+				Location: location,
+			}
+			if p.IsOptional {
+				prop.Modifiers = []string{"optional"}
+			}
+			ctx.addProperty(sym.ID, prop)
+		}
+	}
+}
+
+// extractJSDocParams returns the @param tags of a JSDoc block that has no
+// function to take parameter names from, such as a @callback.
+func extractJSDocParams(doc model.DocBlock) []model.Param {
+	var params []model.Param
+	for _, raw := range doc.Tags["param"] {
+		if p := parseJSDocParam(raw); p.Name != "" && !strings.Contains(p.Name, ".") {
+			params = append(params, p)
+		}
+	}
+	return params
+}
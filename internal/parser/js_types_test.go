@@ -0,0 +1,149 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestParseJSDocParam(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want model.Param
+	}{
+		{"{string} name The name.", model.Param{Name: "name", Type: "string", Description: "The name."}},
+		{"{string=} name Optional name.", model.Param{Name: "name", Type: "string", Description: "Optional name.", IsOptional: true}},
+		{"{?Object=} options", model.Param{Name: "options", Type: "?Object", IsOptional: true}},
+		{"{number} [count=10] How many.", model.Param{Name: "count", Type: "number", Description: "How many.", Default: "10", IsOptional: true}},
+		{"{boolean} [force]", model.Param{Name: "force", Type: "boolean", IsOptional: true}},
+		{"settings Untyped.", model.Param{Name: "settings", Description: "Untyped."}},
+	}
+	for _, tt := range tests {
+		if got := parseJSDocParam(tt.raw); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseJSDocParam(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestTypedefComments(t *testing.T) {
+	src := `/**
+ * Block settings.
+ *
+ * @typedef {Object} WPBlockSettings
+ *
+ * @property {string}   title      Block title.
+ * @property {string=}  [icon]     Block icon.
+ * @property {Object}   supports   Supported features.
+ * @property {boolean}  supports.html Ignored, a nested path.
+ */
+
+/**
+ * @callback WPBlockTransform
+ *
+ * @param {Object} attributes Block attributes.
+ * @return {Object} New attributes.
+ */
+export function registerBlockType( name, settings ) {
+	return settings;
+}
+`
+	reg := extractJSSource(t, "blocks.js", src)
+
+	typedef := reg.Get("blocks.WPBlockSettings")
+	if typedef == nil || typedef.Kind != model.KindTypeAlias {
+		t.Fatalf("WPBlockSettings = %+v, want a type alias", typedef)
+	}
+	if typedef.Type != "Object" || typedef.Doc.Summary != "Block settings." {
+		t.Errorf("WPBlockSettings type %q, summary %q", typedef.Type, typedef.Doc.Summary)
+	}
+	wantMembers := []string{"blocks.WPBlockSettings.title", "blocks.WPBlockSettings.icon", "blocks.WPBlockSettings.supports"}
+	if !reflect.DeepEqual(typedef.Members, wantMembers) {
+		t.Errorf("Members = %q, want %q", typedef.Members, wantMembers)
+	}
+	if icon := reg.Get("blocks.WPBlockSettings.icon"); icon == nil || icon.Type != "string" || !reflect.DeepEqual(icon.Modifiers, []string{"optional"}) {
+		t.Errorf("icon = %+v, want an optional string", icon)
+	}
+
+	callback := reg.Get("blocks.WPBlockTransform")
+	if callback == nil {
+		t.Fatal("WPBlockTransform not extracted")
+	}
+	wantParams := []model.Param{{Name: "attributes", Type: "Object", Description: "Block attributes."}}
+	if !reflect.DeepEqual(callback.Params, wantParams) {
+		t.Errorf("callback Params = %+v, want %+v", callback.Params, wantParams)
+	}
+	if callback.Returns == nil || callback.Returns.Type != "Object" {
+		t.Errorf("callback Returns = %+v, want Object", callback.Returns)
+	}
+
+	// The @callback block right above the function isn't its documentation
+	fn := reg.Get("blocks.registerBlockType")
+	if fn == nil {
+		t.Fatal("registerBlockType not extracted")
+	}
+	if !reflect.DeepEqual(fn.Doc, model.DocBlock{}) {
+		t.Errorf("registerBlockType Doc = %+v, want none", fn.Doc)
+	}
+}
+
+func TestTypeScriptTypes(t *testing.T) {
+	src := `/** Where a notice appears. */
+export enum Placement {
+	Top = 'top',
+	Bottom = 'bottom',
+	Inline,
+}
+
+export type Size = 'small' | 'large';
+
+export type Options = {
+	readonly name: string;
+	/** Extra classes. */
+	className?: string;
+};
+`
+	reg := extractJSSource(t, "types.ts", src)
+
+	enum := reg.Get("types.Placement")
+	if enum == nil || enum.Kind != model.KindEnum || enum.Doc.Summary != "Where a notice appears." {
+		t.Fatalf("Placement = %+v", enum)
+	}
+	var cases []string
+	for _, id := range enum.Members {
+		sym := reg.Get(id)
+		cases = append(cases, sym.Name+"="+sym.Value)
+	}
+	if want := []string{"Top='top'", "Bottom='bottom'", "Inline="}; !reflect.DeepEqual(cases, want) {
+		t.Errorf("cases = %q, want %q", cases, want)
+	}
+
+	if size := reg.Get("types.Size"); size == nil || size.Kind != model.KindTypeAlias || size.Type != "'small' | 'large'" {
+		t.Errorf("Size = %+v", size)
+	}
+
+	options := reg.Get("types.Options")
+	if options == nil || options.Type != "" {
+		t.Fatalf("Options = %+v, want an object type", options)
+	}
+	tests := []struct {
+		id        string
+		typ       string
+		modifiers []string
+		summary   string
+	}{
+		{"types.Options.name", "string", []string{"readonly"}, ""},
+		{"types.Options.className", "string", []string{"optional"}, "Extra classes."},
+	}
+	for _, tt := range tests {
+		prop := reg.Get(tt.id)
+		if prop == nil {
+			t.Errorf("%s not extracted", tt.id)
+			continue
+		}
+		if prop.Kind != model.KindProperty || prop.ParentID != "types.Options" || prop.Type != tt.typ ||
+			!reflect.DeepEqual(prop.Modifiers, tt.modifiers) || prop.Doc.Summary != tt.summary {
+			t.Errorf("%s = %+v", tt.id, prop)
+		}
+	}
+}
@@ -10,10 +10,14 @@ import (
 
 // extractPHP walks the tree-sitter AST and extracts PHP symbols.
 func extractPHP(root *sitter.Node, src []byte, file string, reg *model.Registry) {
+	root, src, consts, done := splitEnumConstants(root, src)
+	defer done()
+
 	ctx := &phpContext{
-		src:  src,
-		file: file,
-		reg:  reg,
+		src:        src,
+		file:       file,
+		reg:        reg,
+		enumConsts: consts,
 	}
 	ctx.processChildren(root, "", nil)
 }
@@ -22,6 +26,32 @@ type phpContext struct {
 	src  []byte
 	file string
 	reg  *model.Registry
+
+	// Classes, interfaces, traits and enums declared in this file. Members
+	// attach to these rather than whatever the registry holds under the same
+	// ID, which may be another file's declaration of a duplicate class.
+	classes map[string]*model.Symbol
+
+	// Constants cut out of enum bodies, see splitEnumConstants.
+	enumConsts *enumConstants
+}
+
+// addClass registers a class-like symbol declared in this file.
+func (ctx *phpContext) addClass(sym *model.Symbol) {
+	if ctx.classes == nil {
+		ctx.classes = make(map[string]*model.Symbol)
+	}
+	ctx.classes[sym.ID] = sym
+	ctx.reg.Add(sym)
+}
+
+// class returns the class-like symbol with the given ID, preferring this
+// file's own declaration.
+func (ctx *phpContext) class(id string) *model.Symbol {
+	if sym, ok := ctx.classes[id]; ok {
+		return sym
+	}
+	return ctx.reg.Get(id)
 }
 
 // processChildren iterates named children, tracking namespace changes across siblings.
@@ -55,7 +85,91 @@ func (ctx *phpContext) processNode(node *sitter.Node, namespace string, classSta
 		ctx.handleInterface(node, namespace, classStack)
 	case "trait_declaration":
 		ctx.handleTrait(node, namespace, classStack)
+	case "enum_declaration":
+		ctx.handleEnum(node, namespace, classStack)
+	case "namespace_use_declaration":
+		ctx.handleUseDeclaration(node, namespace)
+	case "const_declaration":
+		ctx.handleConstDeclaration(node, namespace)
+	case "if_statement", "else_if_clause", "else_clause":
+		ctx.handleConditional(node, namespace, classStack)
+	default:
+		// File-scope statements (e.g. default-filters.php) fire and register hooks too
+		ctx.handleGlobalAssignment(node)
+		scanFileScopeHooks(node, ctx.src, ctx.file, namespace, ctx.reg)
+	}
+}
+
+// handleConditional processes the branches of a file-scope if statement, so
+// declarations guarded by function_exists() or class_exists() (pluggable
+// functions, compat.php polyfills) are extracted like top-level ones.
+func (ctx *phpContext) handleConditional(node *sitter.Node, namespace string, classStack []string) {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch child.Type() {
+		case "parenthesized_expression":
+			// The condition may fire hooks: if ( apply_filters( ... ) )
+			scanFileScopeHooks(child, ctx.src, ctx.file, namespace, ctx.reg)
+		case "compound_statement", "colon_block":
+			ctx.processChildren(child, namespace, classStack)
+		default:
+			// else/elseif clauses and single-statement bodies
+			ctx.processNode(child, namespace, classStack)
+		}
+	}
+}
+
+// handleUseDeclaration records `use` imports (`use A\B as C;`,
+// `use function A\f;`, `use A\{B, C as D};`) in the file's import table for
+// the current namespace, so the resolver can apply PHP's name resolution rules.
+func (ctx *phpContext) handleUseDeclaration(node *sitter.Node, namespace string) {
+	kind, prefix := model.ImportClass, ""
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch child.Type() {
+		case "function":
+			kind = model.ImportFunction
+		case "const":
+			kind = model.ImportConst
+		case "namespace_name":
+			prefix = nodeText(child, ctx.src) // Group prefix: use A\{...}
+		case "namespace_use_clause":
+			ctx.addImport(child, namespace, kind, "")
+		case "namespace_use_group":
+			for _, clause := range childrenByType(child, "namespace_use_group_clause") {
+				ctx.addImport(clause, namespace, kind, prefix)
+			}
+		}
+	}
+}
+
+// addImport records one use clause, defaulting the alias to the last segment
+// of the imported name.
+func (ctx *phpContext) addImport(clause *sitter.Node, namespace string, kind model.ImportKind, prefix string) {
+	var name, alias string
+	for i := 0; i < int(clause.ChildCount()); i++ {
+		child := clause.Child(i)
+		switch child.Type() {
+		case "function":
+			kind = model.ImportFunction // use A\{function f}
+		case "const":
+			kind = model.ImportConst
+		case "name", "qualified_name", "namespace_name":
+			name = phpClassName(child, ctx.src)
+		case "namespace_aliasing_clause":
+			alias = nodeText(child.NamedChild(0), ctx.src)
+		}
+	}
+	if name == "" {
+		return
+	}
+	if prefix != "" {
+		name = strings.TrimPrefix(prefix, "\\") + "\\" + name
+	}
+	if alias == "" {
+		alias = name[strings.LastIndex(name, "\\")+1:]
 	}
+	ctx.reg.AddImport(ctx.file, namespace, kind, alias, name)
 }
 
 func (ctx *phpContext) handleFunction(node *sitter.Node, namespace string) {
@@ -69,25 +183,30 @@ func (ctx *phpContext) handleFunction(node *sitter.Node, namespace string) {
 	doc := findDocComment(node, ctx.src)
 
 	sym := &model.Symbol{
-		ID:       fqn,
-		Name:     name,
-		Kind:     model.KindFunction,
-		Language: "php",
-		Doc:      doc,
-		Params:   extractPHPParams(node.ChildByFieldName("parameters"), ctx.src, doc),
-		Returns:  ParseReturn(doc),
+		ID:        fqn,
+		Name:      name,
+		Kind:      model.KindFunction,
+		Language:  "php",
+		Namespace: namespace,
+		Doc:       doc,
+		Params:    extractPHPParams(node.ChildByFieldName("parameters"), ctx.src, doc),
+		Returns:   phpReturn(node, ctx.src, doc),
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
 			EndLine:   endLine(node),
 		},
 	}
-	ctx.reg.Add(sym)
+	if body := node.ChildByFieldName("body"); body != nil {
+		sym.Calls = scanForCalls(body, ctx.src)
+	}
+	registerDocGlobals(sym, ctx.reg)
 
 	// Scan function body for hooks
 	if body := node.ChildByFieldName("body"); body != nil {
-		scanForHooks(body, ctx.src, ctx.file, fqn, ctx.reg)
+		scanForHooks(body, ctx.src, ctx.file, namespace, sym, ctx.reg)
 	}
+	ctx.reg.Add(sym)
 }
 
 func (ctx *phpContext) handleClass(node *sitter.Node, namespace string, classStack []string) {
@@ -100,11 +219,13 @@ func (ctx *phpContext) handleClass(node *sitter.Node, namespace string, classSta
 
 	doc := findDocComment(node, ctx.src)
 	sym := &model.Symbol{
-		ID:       fqn,
-		Name:     name,
-		Kind:     model.KindClass,
-		Language: "php",
-		Doc:      doc,
+		ID:        fqn,
+		Name:      name,
+		Kind:      model.KindClass,
+		Language:  "php",
+		Namespace: namespace,
+		Doc:       doc,
+		Modifiers: phpModifiers(node, ctx.src),
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
@@ -126,7 +247,7 @@ func (ctx *phpContext) handleClass(node *sitter.Node, namespace string, classSta
 		}
 	}
 
-	ctx.reg.Add(sym)
+	ctx.addClass(sym)
 
 	// Process class body members
 	if body := childByType(node, "declaration_list"); body != nil {
@@ -145,11 +266,12 @@ func (ctx *phpContext) handleInterface(node *sitter.Node, namespace string, clas
 
 	doc := findDocComment(node, ctx.src)
 	sym := &model.Symbol{
-		ID:       fqn,
-		Name:     name,
-		Kind:     model.KindInterface,
-		Language: "php",
-		Doc:      doc,
+		ID:        fqn,
+		Name:      name,
+		Kind:      model.KindInterface,
+		Language:  "php",
+		Namespace: namespace,
+		Doc:       doc,
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
@@ -164,7 +286,7 @@ func (ctx *phpContext) handleInterface(node *sitter.Node, namespace string, clas
 		}
 	}
 
-	ctx.reg.Add(sym)
+	ctx.addClass(sym)
 
 	if body := childByType(node, "declaration_list"); body != nil {
 		newStack := append(append([]string{}, classStack...), fqn)
@@ -182,18 +304,19 @@ func (ctx *phpContext) handleTrait(node *sitter.Node, namespace string, classSta
 
 	doc := findDocComment(node, ctx.src)
 	sym := &model.Symbol{
-		ID:       fqn,
-		Name:     name,
-		Kind:     model.KindTrait,
-		Language: "php",
-		Doc:      doc,
+		ID:        fqn,
+		Name:      name,
+		Kind:      model.KindTrait,
+		Language:  "php",
+		Namespace: namespace,
+		Doc:       doc,
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
 			EndLine:   endLine(node),
 		},
 	}
-	ctx.reg.Add(sym)
+	ctx.addClass(sym)
 
 	if body := childByType(node, "declaration_list"); body != nil {
 		newStack := append(append([]string{}, classStack...), fqn)
@@ -201,16 +324,289 @@ func (ctx *phpContext) handleTrait(node *sitter.Node, namespace string, classSta
 	}
 }
 
-// processClassBody handles method declarations inside a class/interface/trait body.
+// handleEnum extracts a PHP 8.1 enum with its backing type, interfaces and cases.
+func (ctx *phpContext) handleEnum(node *sitter.Node, namespace string, classStack []string) {
+	nameNode := node.ChildByFieldName("name")
+	name := nodeText(nameNode, ctx.src)
+	if name == "" {
+		return
+	}
+	fqn := qualifyPHP(namespace, name)
+
+	doc := findDocComment(node, ctx.src)
+	sym := &model.Symbol{
+		ID:        fqn,
+		Name:      name,
+		Kind:      model.KindEnum,
+		Language:  "php",
+		Namespace: namespace,
+		Doc:       doc,
+		Location: model.SourceLocation{
+			File:      ctx.file,
+			StartLine: startLine(node),
+			EndLine:   endLine(node),
+		},
+	}
+
+	// Backed enum: `enum Suit: string`
+	if bt := childByType(node, "primitive_type"); bt != nil {
+		sym.Type = nodeText(bt, ctx.src)
+	}
+
+	if ic := childByType(node, "class_interface_clause"); ic != nil {
+		for i := 0; i < int(ic.NamedChildCount()); i++ {
+			sym.Implements = append(sym.Implements, nodeText(ic.NamedChild(i), ctx.src))
+		}
+	}
+
+	ctx.addClass(sym)
+
+	if body := node.ChildByFieldName("body"); body != nil {
+		newStack := append(append([]string{}, classStack...), fqn)
+		ctx.processClassBody(body, namespace, newStack)
+
+		if consts := ctx.enumConsts; consts != nil && len(consts.decls[startLine(body)]) > 0 {
+			src := ctx.src
+			ctx.src = consts.src
+			for _, decl := range consts.decls[startLine(body)] {
+				ctx.handleClassConstant(decl, namespace, newStack)
+			}
+			ctx.src = src
+		}
+	}
+}
+
+// processClassBody handles member declarations inside a class/interface/trait body.
 func (ctx *phpContext) processClassBody(body *sitter.Node, namespace string, classStack []string) {
 	for i := 0; i < int(body.NamedChildCount()); i++ {
 		child := body.NamedChild(i)
-		if child.Type() == "method_declaration" {
+		switch child.Type() {
+		case "method_declaration":
 			ctx.handleMethod(child, namespace, classStack)
+		case "property_declaration":
+			ctx.handleProperty(child, namespace, classStack)
+		case "const_declaration":
+			ctx.handleClassConstant(child, namespace, classStack)
+		case "enum_case":
+			ctx.handleEnumCase(child, namespace, classStack)
+		case "use_declaration":
+			ctx.handleTraitUse(child, classStack)
 		}
 	}
 }
 
+// handleTraitUse records the traits composed into the enclosing class by a
+// `use A, B { A::hello insteadof B; hello as protected greet; }` declaration.
+func (ctx *phpContext) handleTraitUse(node *sitter.Node, classStack []string) {
+	if len(classStack) == 0 {
+		return
+	}
+	class := ctx.class(classStack[len(classStack)-1])
+	if class == nil {
+		return
+	}
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch child.Type() {
+		case "name", "qualified_name":
+			class.Traits = append(class.Traits, phpClassName(child, ctx.src))
+		case "use_list":
+			for j := 0; j < int(child.NamedChildCount()); j++ {
+				if rule, ok := ctx.traitRule(child.NamedChild(j)); ok {
+					class.TraitRules = append(class.TraitRules, rule)
+				}
+			}
+		}
+	}
+}
+
+// traitRule parses a use_instead_of_clause or use_as_clause.
+func (ctx *phpContext) traitRule(node *sitter.Node) (model.TraitRule, bool) {
+	var rule model.TraitRule
+	if node.Type() != "use_instead_of_clause" && node.Type() != "use_as_clause" {
+		return rule, false
+	}
+
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch {
+		case i == 0 && child.Type() == "class_constant_access_expression":
+			// A::hello
+			if child.NamedChildCount() == 2 {
+				rule.Trait = phpClassName(child.NamedChild(0), ctx.src)
+				rule.Method = nodeText(child.NamedChild(1), ctx.src)
+			}
+		case i == 0:
+			rule.Method = nodeText(child, ctx.src)
+		case child.Type() == "visibility_modifier":
+			rule.Visibility = nodeText(child, ctx.src)
+		case node.Type() == "use_instead_of_clause":
+			rule.InsteadOf = append(rule.InsteadOf, phpClassName(child, ctx.src))
+		default:
+			rule.Alias = nodeText(child, ctx.src)
+		}
+	}
+	return rule, rule.Method != ""
+}
+
+// phpClassName returns a class reference as written, without a leading backslash.
+func phpClassName(node *sitter.Node, src []byte) string {
+	return strings.TrimPrefix(nodeText(node, src), "\\")
+}
+
+// handleProperty registers each property in a declaration such as
+// `public static ?int $a = 1, $b;` as its own symbol. The docblock is shared.
+func (ctx *phpContext) handleProperty(node *sitter.Node, namespace string, classStack []string) {
+	if len(classStack) == 0 {
+		return
+	}
+	classFQN := classStack[len(classStack)-1]
+
+	doc := findDocComment(node, ctx.src)
+	varType, varDesc := ParseVar(doc)
+	if doc.Summary == "" {
+		doc.Summary = varDesc
+	}
+
+	typeName := nodeText(node.ChildByFieldName("type"), ctx.src)
+	if typeName == "" {
+		typeName = varType
+	}
+	modifiers := phpModifiers(node, ctx.src)
+
+	for _, el := range childrenByType(node, "property_element") {
+		name := strings.TrimPrefix(nodeText(childByType(el, "variable_name"), ctx.src), "$")
+		if name == "" {
+			continue
+		}
+		var value string
+		if init := childByType(el, "property_initializer"); init != nil && init.NamedChildCount() > 0 {
+			value = nodeText(init.NamedChild(0), ctx.src)
+		}
+
+		propID := classFQN + "::$" + name
+		ctx.reg.Add(&model.Symbol{
+			ID:        propID,
+			Name:      name,
+			Kind:      model.KindProperty,
+			Language:  "php",
+			Namespace: namespace,
+			Doc:       doc,
+			Type:      typeName,
+			Value:     value,
+			Modifiers: modifiers,
+			ParentID:  classFQN,
+			Location: model.SourceLocation{
+				File:      ctx.file,
+				StartLine: startLine(el),
+				EndLine:   endLine(el),
+			},
+		})
+
+		if parent := ctx.class(classFQN); parent != nil {
+			parent.Members = append(parent.Members, propID)
+		}
+	}
+}
+
+// handleClassConstant registers each constant in a class `const A = 1, B = 2;` declaration.
+func (ctx *phpContext) handleClassConstant(node *sitter.Node, namespace string, classStack []string) {
+	if len(classStack) == 0 {
+		return
+	}
+	classFQN := classStack[len(classStack)-1]
+
+	doc := findDocComment(node, ctx.src)
+	varType, varDesc := ParseVar(doc)
+	if doc.Summary == "" {
+		doc.Summary = varDesc
+	}
+	modifiers := phpModifiers(node, ctx.src)
+
+	for _, el := range childrenByType(node, "const_element") {
+		name := nodeText(childByType(el, "name"), ctx.src)
+		if name == "" {
+			continue
+		}
+		var value string
+		if n := el.NamedChildCount(); n > 1 {
+			value = nodeText(el.NamedChild(int(n)-1), ctx.src)
+		}
+
+		constID := classFQN + "::" + name
+		ctx.reg.Add(&model.Symbol{
+			ID:        constID,
+			Name:      name,
+			Kind:      model.KindConstant,
+			Language:  "php",
+			Namespace: namespace,
+			Doc:       doc,
+			Type:      varType,
+			Value:     value,
+			Modifiers: modifiers,
+			ParentID:  classFQN,
+			Location: model.SourceLocation{
+				File:      ctx.file,
+				StartLine: startLine(el),
+				EndLine:   endLine(el),
+			},
+		})
+
+		if parent := ctx.class(classFQN); parent != nil {
+			parent.Members = append(parent.Members, constID)
+		}
+	}
+}
+
+// handleEnumCase registers a `case Hearts = 'H';` entry of an enum.
+func (ctx *phpContext) handleEnumCase(node *sitter.Node, namespace string, classStack []string) {
+	name := nodeText(node.ChildByFieldName("name"), ctx.src)
+	if name == "" || len(classStack) == 0 {
+		return
+	}
+	enumFQN := classStack[len(classStack)-1]
+	caseID := enumFQN + "::" + name
+
+	ctx.reg.Add(&model.Symbol{
+		ID:        caseID,
+		Name:      name,
+		Kind:      model.KindEnumCase,
+		Language:  "php",
+		Namespace: namespace,
+		Doc:       findDocComment(node, ctx.src),
+		Value:     nodeText(node.ChildByFieldName("value"), ctx.src),
+		ParentID:  enumFQN,
+		Location: model.SourceLocation{
+			File:      ctx.file,
+			StartLine: startLine(node),
+			EndLine:   endLine(node),
+		},
+	})
+
+	if parent := ctx.class(enumFQN); parent != nil {
+		parent.Members = append(parent.Members, caseID)
+	}
+}
+
+// phpModifiers collects the modifier keywords (abstract, final, visibility,
+// static, readonly) declared directly on a class or member node, in source
+// order. The legacy `var` keyword means public.
+func phpModifiers(node *sitter.Node, src []byte) []string {
+	var mods []string
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		switch child.Type() {
+		case "var_modifier":
+			mods = append(mods, "public")
+		case "visibility_modifier", "static_modifier", "readonly_modifier",
+			"abstract_modifier", "final_modifier":
+			mods = append(mods, nodeText(child, src))
+		}
+	}
+	return mods
+}
+
 func (ctx *phpContext) handleMethod(node *sitter.Node, namespace string, classStack []string) {
 	nameNode := node.ChildByFieldName("name")
 	name := nodeText(nameNode, ctx.src)
@@ -222,30 +618,36 @@ func (ctx *phpContext) handleMethod(node *sitter.Node, namespace string, classSt
 
 	doc := findDocComment(node, ctx.src)
 	sym := &model.Symbol{
-		ID:       methodID,
-		Name:     name,
-		Kind:     model.KindMethod,
-		Language: "php",
-		Doc:      doc,
-		Params:   extractPHPParams(node.ChildByFieldName("parameters"), ctx.src, doc),
-		Returns:  ParseReturn(doc),
-		ParentID: classFQN,
+		ID:        methodID,
+		Name:      name,
+		Kind:      model.KindMethod,
+		Language:  "php",
+		Namespace: namespace,
+		Doc:       doc,
+		Modifiers: phpModifiers(node, ctx.src),
+		Params:    extractPHPParams(node.ChildByFieldName("parameters"), ctx.src, doc),
+		Returns:   phpReturn(node, ctx.src, doc),
+		ParentID:  classFQN,
 		Location: model.SourceLocation{
 			File:      ctx.file,
 			StartLine: startLine(node),
 			EndLine:   endLine(node),
 		},
 	}
-	ctx.reg.Add(sym)
-
-	// Register method under parent class
-	if parent := ctx.reg.Get(classFQN); parent != nil {
-		parent.Members = append(parent.Members, methodID)
+	if body := node.ChildByFieldName("body"); body != nil {
+		sym.Calls = scanForCalls(body, ctx.src)
 	}
+	registerDocGlobals(sym, ctx.reg)
 
 	// Scan method body for hooks
 	if body := node.ChildByFieldName("body"); body != nil {
-		scanForHooks(body, ctx.src, ctx.file, methodID, ctx.reg)
+		scanForHooks(body, ctx.src, ctx.file, namespace, sym, ctx.reg)
+	}
+	ctx.reg.Add(sym)
+
+	// Register method under parent class
+	if parent := ctx.class(classFQN); parent != nil {
+		parent.Members = append(parent.Members, methodID)
 	}
 }
 
@@ -271,7 +673,9 @@ func extractPHPParams(paramsNode *sitter.Node, src []byte, doc model.DocBlock) [
 	var result []model.Param
 	for i := 0; i < int(paramsNode.NamedChildCount()); i++ {
 		param := paramsNode.NamedChild(i)
-		if param.Type() != "simple_parameter" && param.Type() != "property_promotion_parameter" {
+		switch param.Type() {
+		case "simple_parameter", "variadic_parameter", "property_promotion_parameter":
+		default:
 			continue
 		}
 
@@ -285,8 +689,14 @@ func extractPHPParams(paramsNode *sitter.Node, src []byte, doc model.DocBlock) [
 		mp := model.Param{Name: name}
 
 		// Type from AST
-		if typeNode := param.ChildByFieldName("type"); typeNode != nil {
-			mp.Type = nodeText(typeNode, src)
+		if mp.Native = phpType(param.ChildByFieldName("type"), src); mp.Native != nil {
+			mp.Type = mp.Native.String()
+			mp.IsNullable = mp.Native.IsNullable()
+		}
+
+		// Default value from AST
+		if def := param.ChildByFieldName("default_value"); def != nil {
+			mp.Default = nodeText(def, src)
 		}
 
 		// Merge doc info
@@ -294,8 +704,13 @@ func extractPHPParams(paramsNode *sitter.Node, src []byte, doc model.DocBlock) [
 			if mp.Type == "" {
 				mp.Type = dp.Type
 			}
+			mp.DocType = dp.Type
 			mp.Description = dp.Description
-			mp.IsNullable = dp.IsNullable
+			mp.IsNullable = mp.IsNullable || dp.IsNullable
+			mp.Fields = dp.Fields
+			if mp.Default == "" {
+				mp.Default = ParseDocDefault(dp.Description)
+			}
 		}
 
 		// Check for variadic and reference via parameter text
@@ -0,0 +1,76 @@
+package parser
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// scanForCalls walks a function or method body and records every statically
+// nameable call: plain function calls, $obj->method() calls, Class::method()
+// calls and `new Class` expressions. Dynamic calls ($fn(), $obj->$name())
+// are skipped since they cannot be resolved without type inference.
+func scanForCalls(bodyNode *sitter.Node, src []byte) []model.CallRef {
+	var calls []model.CallRef
+	seen := make(map[model.CallRef]bool)
+	add := func(c model.CallRef) {
+		key := c
+		key.Line = 0
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		calls = append(calls, c)
+	}
+
+	walkTree(bodyNode, func(node *sitter.Node) {
+		switch node.Type() {
+		case "function_call_expression":
+			fn := node.ChildByFieldName("function")
+			if fn == nil || (fn.Type() != "name" && fn.Type() != "qualified_name") {
+				return
+			}
+			add(model.CallRef{Kind: "function", Name: nodeText(fn, src), Line: startLine(node)})
+
+		case "member_call_expression", "nullsafe_member_call_expression":
+			name := node.ChildByFieldName("name")
+			if name == nil || name.Type() != "name" {
+				return
+			}
+			ref := model.CallRef{Kind: "method", Name: nodeText(name, src), Line: startLine(node)}
+			// The receiver's class can only be known for $this and plain
+			// variables, whose type may be declared as a parameter.
+			if obj := node.ChildByFieldName("object"); obj != nil && obj.Type() == "variable_name" {
+				ref.Class = nodeText(obj, src)
+			}
+			add(ref)
+
+		case "scoped_call_expression":
+			scope := node.ChildByFieldName("scope")
+			name := node.ChildByFieldName("name")
+			if scope == nil || name == nil || name.Type() != "name" {
+				return
+			}
+			if scope.Type() != "name" && scope.Type() != "qualified_name" && scope.Type() != "relative_scope" {
+				return
+			}
+			add(model.CallRef{
+				Kind:  "static",
+				Class: nodeText(scope, src),
+				Name:  nodeText(name, src),
+				Line:  startLine(node),
+			})
+
+		case "object_creation_expression":
+			class := childByType(node, "name")
+			if class == nil {
+				class = childByType(node, "qualified_name")
+			}
+			if class == nil {
+				return
+			}
+			add(model.CallRef{Kind: "new", Class: nodeText(class, src), Line: startLine(node)})
+		}
+	})
+	return calls
+}
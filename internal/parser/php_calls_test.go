@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestScanForCalls(t *testing.T) {
+	src := `<?php
+function publish( Post $post ) {
+	$post->update();
+	$this->save();
+	$post?->update(); // Same call as above
+	get_post()->save();
+	$post->$method();
+	$fn();
+	Post::find( 1 );
+	parent::save();
+	$class::make();
+	new \App\Post();
+	new $class();
+	wp_insert_post( array() );
+}
+`
+	reg := extractPHPSource(t, "calls.php", src)
+	sym := reg.Get("publish")
+	if sym == nil {
+		t.Fatal("publish not extracted")
+	}
+	want := []model.CallRef{
+		{Kind: "method", Class: "$post", Name: "update", Line: 3},
+		{Kind: "method", Class: "$this", Name: "save", Line: 4},
+		{Kind: "method", Name: "save", Line: 6},
+		{Kind: "function", Name: "get_post", Line: 6},
+		{Kind: "static", Class: "Post", Name: "find", Line: 9},
+		{Kind: "static", Class: "parent", Name: "save", Line: 10},
+		{Kind: "new", Class: `\App\Post`, Line: 12},
+		{Kind: "function", Name: "wp_insert_post", Line: 14},
+	}
+	if !reflect.DeepEqual(sym.Calls, want) {
+		t.Errorf("Calls =\n%+v\nwant\n%+v", sym.Calls, want)
+	}
+}
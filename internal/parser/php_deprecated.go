@@ -0,0 +1,116 @@
+package parser
+
+import (
+	"path"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// Deprecation helpers we detect, mapped to the argument positions of the
+// version, replacement and message arguments (-1 when absent).
+var deprecationFunctions = map[string]struct{ version, replacement, message int }{
+	"_deprecated_function":     {1, 2, -1},
+	"_deprecated_argument":     {1, -1, 2},
+	"_deprecated_hook":         {1, 2, 3},
+	"_deprecated_file":         {1, 2, 3},
+	"apply_filters_deprecated": {2, 3, 4},
+	"do_action_deprecated":     {2, 3, 4},
+}
+
+// registerDeprecation records a _deprecated_*() notice (or the deprecation
+// half of apply_filters_deprecated/do_action_deprecated) for the resolver to
+// attach to the deprecated symbol.
+func registerDeprecation(call *sitter.Node, fnName string, callerID string, src []byte, file string, reg *model.Registry) {
+	pos := deprecationFunctions[fnName]
+	args := callArguments(call)
+	if len(args) == 0 {
+		return
+	}
+
+	d := model.Deprecation{
+		Function:    fnName,
+		Version:     deprecationArg(args, pos.version, src),
+		Replacement: deprecationArg(args, pos.replacement, src),
+		Message:     deprecationArg(args, pos.message, src),
+		File:        file,
+		Line:        startLine(call),
+	}
+
+	switch fnName {
+	case "_deprecated_function", "_deprecated_argument":
+		d.TargetID = deprecatedFunctionID(args[0], src, callerID)
+	case "_deprecated_hook", "apply_filters_deprecated", "do_action_deprecated":
+		tag := extractHookTag(args[0], src)
+		if tag == "" {
+			return // e.g. _deprecated_hook( $hook_name, ... ) inside the helper itself
+		}
+		d.TargetID = "hook:" + tag
+	case "_deprecated_file":
+		d.TargetFile = deprecatedFilePath(args[0], src, file)
+		d.Replacement = phpPathExpr(d.Replacement)
+	}
+	if d.TargetID == "" && d.TargetFile == "" {
+		return
+	}
+	reg.AddDeprecation(d)
+}
+
+// deprecationArg returns the argument at index i as a plain string: string
+// literals are unquoted, anything else is kept as written.
+func deprecationArg(args []*sitter.Node, i int, src []byte) string {
+	if i < 0 || i >= len(args) {
+		return ""
+	}
+	arg := args[i]
+	if arg.Type() == "string" || arg.Type() == "encapsed_string" {
+		return strings.TrimSpace(unquotePHP(nodeText(arg, src)))
+	}
+	text := nodeText(arg, src)
+	// __( 'Use foo() instead.' ) and friends: keep just the text.
+	if arg.Type() == "function_call_expression" {
+		if inner := callArguments(arg); len(inner) > 0 && inner[0].Type() == "string" {
+			return strings.TrimSpace(unquotePHP(nodeText(inner[0], src)))
+		}
+	}
+	return text
+}
+
+// deprecatedFunctionID resolves the first argument of _deprecated_function()
+// to a symbol ID. __FUNCTION__, __METHOD__ and friends refer to the enclosing
+// symbol; string literals name the function or "Class::method" directly.
+func deprecatedFunctionID(arg *sitter.Node, src []byte, callerID string) string {
+	if arg.Type() == "string" {
+		return strings.TrimPrefix(strings.TrimSuffix(unquotePHP(nodeText(arg, src)), "()"), "\\")
+	}
+	return callerID
+}
+
+// deprecatedFilePath resolves the first argument of _deprecated_file(). Core
+// passes basename( __FILE__ ) or the file's own name, both meaning the current
+// file; any other literal is kept for the resolver to match.
+func deprecatedFilePath(arg *sitter.Node, src []byte, file string) string {
+	text := nodeText(arg, src)
+	if strings.Contains(text, "__FILE__") {
+		return file
+	}
+	name := unquotePHP(text)
+	if name == path.Base(file) || name == "" {
+		return file
+	}
+	return name
+}
+
+// phpPathExpr renders common core path expressions such as
+// ABSPATH . WPINC . '/user.php' as a plain path ("wp-includes/user.php").
+// Other expressions are returned unchanged.
+func phpPathExpr(expr string) string {
+	r := strings.NewReplacer("ABSPATH . ", "", "WPINC . '/", "wp-includes/", "WPINC . \"/", "wp-includes/")
+	path := strings.Trim(r.Replace(expr), "'\"")
+	if path == strings.Trim(expr, "'\"") || strings.ContainsAny(path, "'\" $") {
+		return expr
+	}
+	return path
+}
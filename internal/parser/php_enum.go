@@ -0,0 +1,159 @@
+package parser
+
+import (
+	"bytes"
+	"context"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/php"
+)
+
+// The PHP grammar doesn't accept constants in enum bodies, which PHP 8.1
+// allows. Error recovery ends the enum at the first `const` and leaks the rest
+// of its body into the file, where its methods would pass for functions. The
+// constants themselves come out of recovery as well-formed const_declaration
+// nodes, so they are taken from the original tree and blanked in a copy of the
+// source, which then parses cleanly. The copy keeps the original offsets.
+
+// enumConstants holds the constant declarations recovered from the enums that
+// were cut short, keyed by the line their body starts on, and the source the
+// declarations belong to.
+type enumConstants struct {
+	decls map[int][]*sitter.Node
+	src   []byte
+}
+
+// splitEnumConstants reparses a PHP file whose enums were cut short by a
+// constant. It returns the tree and source to extract from, the enums'
+// constants (nil if no enum needed it, in which case root and src are
+// returned as they are), and a function that frees the new tree.
+func splitEnumConstants(root *sitter.Node, src []byte) (*sitter.Node, []byte, *enumConstants, func()) {
+	consts := &enumConstants{decls: make(map[int][]*sitter.Node), src: src}
+	var ranges [][2]uint32
+	walkTree(root, func(n *sitter.Node) {
+		open := brokenEnumBody(n)
+		if open == nil {
+			return
+		}
+		decls, declRanges, ok := recoverEnumConstants(root, open, src)
+		if ok && len(decls) > 0 {
+			consts.decls[startLine(open)] = decls
+			ranges = append(ranges, declRanges...)
+		}
+	})
+	if len(ranges) == 0 {
+		return root, src, nil, func() {}
+	}
+
+	masked := bytes.Clone(src)
+	for _, r := range ranges {
+		for i := r[0]; i < r[1]; i++ {
+			if masked[i] != '\n' {
+				masked[i] = ' '
+			}
+		}
+	}
+
+	sp := sitter.NewParser()
+	defer sp.Close()
+	sp.SetLanguage(php.GetLanguage())
+	tree, err := sp.ParseCtx(context.Background(), nil, masked)
+	if err != nil {
+		return root, src, nil, func() {}
+	}
+	return tree.RootNode(), masked, consts, tree.Close
+}
+
+// brokenEnumBody returns the opening brace of an enum body that error
+// recovery cut short, or nil. Depending on what precedes the first constant,
+// the enum is either kept with a missing closing brace or becomes an ERROR
+// node itself.
+func brokenEnumBody(n *sitter.Node) *sitter.Node {
+	switch n.Type() {
+	case "enum_declaration":
+		body := n.ChildByFieldName("body")
+		if body != nil && body.HasError() && body.ChildCount() > 0 {
+			return body.Child(0)
+		}
+	case "ERROR":
+		if n.ChildCount() == 0 || n.Child(0).Type() != "enum" {
+			return nil
+		}
+		for i := 0; i < int(n.ChildCount()); i++ {
+			if n.Child(i).Type() == "{" {
+				return n.Child(i)
+			}
+		}
+	}
+	return nil
+}
+
+// recoverEnumConstants walks the tokens of the tree from the enum body's
+// opening brace to the brace that really closes it, collecting the constant
+// declarations directly inside the body. Each one's byte range includes its
+// docblock and attributes. Since the grammar has already tokenized strings,
+// heredocs and comments, only real braces and semicolons are counted.
+func recoverEnumConstants(root, open *sitter.Node, src []byte) ([]*sitter.Node, [][2]uint32, bool) {
+	var decls []*sitter.Node
+	var ranges [][2]uint32
+	from := open.StartByte()
+	depth, closed := 0, false
+	stmt, doc := -1, -1
+
+	var visit func(n *sitter.Node)
+	visit = func(n *sitter.Node) {
+		if closed || n.EndByte() <= from {
+			return
+		}
+		if n.Type() == "const_declaration" && depth == 1 && n.StartByte() > from {
+			start := int(n.StartByte())
+			if doc >= 0 {
+				start = doc
+			} else if stmt >= 0 {
+				start = stmt
+			}
+			decls = append(decls, n)
+			ranges = append(ranges, [2]uint32{uint32(start), n.EndByte()})
+		}
+		if n.ChildCount() > 0 {
+			for i := 0; i < int(n.ChildCount()); i++ {
+				visit(n.Child(i))
+			}
+			return
+		}
+		if n.IsMissing() || n.StartByte() < from {
+			return
+		}
+
+		pos := int(n.StartByte())
+		switch n.Type() {
+		case "{":
+			if depth == 1 && stmt < 0 {
+				stmt = pos
+			}
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				closed = true
+			} else if depth == 1 {
+				// End of a method body or trait use block
+				stmt, doc = -1, -1
+			}
+		case ";":
+			if depth == 1 {
+				stmt, doc = -1, -1
+			}
+		case "comment":
+			if depth == 1 && stmt < 0 && bytes.HasPrefix(src[pos:], []byte("/**")) {
+				doc = pos
+			}
+		default:
+			if depth == 1 && stmt < 0 {
+				stmt = pos
+			}
+		}
+	}
+	visit(root)
+	return decls, ranges, closed
+}
@@ -0,0 +1,151 @@
+package parser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smacker/go-tree-sitter/php"
+)
+
+func TestSplitEnumConstants(t *testing.T) {
+	tests := []struct {
+		name   string
+		src    string
+		consts []string // constant names recovered, nil if the tree was left alone
+	}{
+		{
+			name: "no constants",
+			src: `<?php
+enum Suit: string {
+	case Hearts = 'H';
+	public function label(): string { return 'x'; }
+}`,
+		},
+		{
+			name: "constants with docblocks",
+			src: `<?php
+enum Suit: string {
+	/** The default suit. */
+	const DEFAULT = self::Hearts;
+	case Hearts = 'H';
+	/**
+	 * Both colours.
+	 */
+	final public const RED = 'r', BLACK = 'b';
+	case Spades = 'S';
+}`,
+			consts: []string{"DEFAULT", "RED", "BLACK"},
+		},
+		{
+			name: "heredoc with apostrophe and unbalanced brace",
+			src: `<?php
+enum Status {
+	const ON = 1;
+	public function help(): string {
+		return <<<EOT
+		Don't { stop
+		EOT;
+	}
+	public function raw(): string {
+		return <<<'EOT'
+		it's } here;
+		EOT;
+	}
+	const OFF = 0;
+}`,
+			consts: []string{"ON", "OFF"},
+		},
+		{
+			name: "attributes, comments and nested classes",
+			src: `<?php
+enum Mode {
+	case A;
+	// A 'quoted' { comment
+	#[Deprecated]
+	const LEGACY = 'a';
+	public function make() {
+		return new class { const INNER = 1; };
+	}
+}`,
+			consts: []string{"LEGACY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := parseTree(t, php.GetLanguage(), tt.src)
+			newRoot, src, consts, done := splitEnumConstants(root, []byte(tt.src))
+			defer done()
+
+			if tt.consts == nil {
+				if consts != nil || newRoot != root || string(src) != tt.src {
+					t.Fatalf("tree was reparsed for an enum without constants")
+				}
+				return
+			}
+			if consts == nil {
+				t.Fatalf("no constants recovered")
+			}
+			if newRoot.HasError() {
+				t.Errorf("masked source still has errors: %s", newRoot.String())
+			}
+			if len(src) != len(tt.src) || strings.Count(string(src), "\n") != strings.Count(tt.src, "\n") {
+				t.Errorf("masked source changed offsets")
+			}
+
+			var names []string
+			for _, decls := range consts.decls {
+				for _, decl := range decls {
+					for _, el := range childrenByType(decl, "const_element") {
+						names = append(names, nodeText(childByType(el, "name"), consts.src))
+					}
+				}
+			}
+			if !reflect.DeepEqual(names, tt.consts) {
+				t.Errorf("constants = %v, want %v", names, tt.consts)
+			}
+			for _, name := range tt.consts {
+				if strings.Contains(string(src), name+" =") {
+					t.Errorf("constant %s left in masked source", name)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractEnumWithConstants(t *testing.T) {
+	reg := extractPHPSource(t, "enum.php", `<?php
+namespace App;
+enum Suit: string {
+	case Hearts = 'H';
+	/** The default suit. */
+	const DEFAULT = self::Hearts;
+	public function label(): string {
+		return <<<EOT
+		Don't {
+		EOT;
+	}
+	case Spades = 'S';
+}
+function after() {}
+`)
+
+	suit := reg.Get(`App\Suit`)
+	if suit == nil {
+		t.Fatal("enum not extracted")
+	}
+	want := []string{`App\Suit::Hearts`, `App\Suit::label`, `App\Suit::Spades`, `App\Suit::DEFAULT`}
+	if !reflect.DeepEqual(suit.Members, want) {
+		t.Errorf("members = %v, want %v", suit.Members, want)
+	}
+	if c := reg.Get(`App\Suit::DEFAULT`); c == nil || c.Doc.Summary != "The default suit." || c.Location.StartLine != 6 {
+		t.Errorf("constant = %+v, want documented on line 6", c)
+	}
+	if reg.Get(`App\label`) != nil || reg.Get("label") != nil {
+		t.Error("enum method leaked as a function")
+	}
+	if f := reg.Get(`App\after`); f == nil || f.Location.StartLine != 14 {
+		t.Errorf("function after the enum = %+v", f)
+	}
+}
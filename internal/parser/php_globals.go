@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// registerDefine records a define( 'NAME', value ) call as a global constant.
+// A define guarded by `if ( ! defined( 'NAME' ) )` (or `defined( 'NAME' ) ||`)
+// can be set earlier, e.g. in wp-config.php, and is marked overridable.
+func registerDefine(call *sitter.Node, src []byte, file string, reg *model.Registry) {
+	args := callArguments(call)
+	if len(args) < 2 || args[0].Type() != "string" {
+		return
+	}
+	name := strings.TrimPrefix(unquotePHP(nodeText(args[0], src)), "\\")
+	if name == "" {
+		return
+	}
+
+	guard := definedGuard(call, name, src)
+	doc := hookDocComment(call, src)
+	if hookDocRank(doc) == 0 && guard != nil && guard.Type() == "if_statement" {
+		doc = findDocComment(guard, src)
+	}
+
+	sym := &model.Symbol{
+		ID:            "const:" + name,
+		Name:          name,
+		Kind:          model.KindConstant,
+		Language:      "php",
+		Doc:           doc,
+		Value:         nodeText(args[1], src),
+		IsOverridable: guard != nil,
+		Location: model.SourceLocation{
+			File:      file,
+			StartLine: startLine(call),
+			EndLine:   endLine(call),
+		},
+	}
+	sym.Type, _ = ParseVar(doc)
+	addGlobalSymbol(sym, reg)
+}
+
+// definedGuard returns the if statement or || expression that makes a define
+// conditional on the constant not being defined yet, or nil.
+func definedGuard(call *sitter.Node, name string, src []byte) *sitter.Node {
+	check := regexp.MustCompile(`defined\s*\(\s*['"]` + regexp.QuoteMeta(name) + `['"]\s*\)`)
+	for node := call.Parent(); node != nil; node = node.Parent() {
+		switch node.Type() {
+		case "function_definition", "method_declaration", "program":
+			return nil
+		case "if_statement":
+			cond := nodeText(node.ChildByFieldName("condition"), src)
+			if loc := check.FindStringIndex(cond); loc != nil && strings.Contains(cond[:loc[0]], "!") {
+				return node
+			}
+		case "binary_expression":
+			// defined( 'NAME' ) || define( 'NAME', ... );
+			left := nodeText(node.ChildByFieldName("left"), src)
+			if check.MatchString(left) && !strings.Contains(left, "!") {
+				return node
+			}
+		}
+	}
+	return nil
+}
+
+// handleConstDeclaration registers a file-scope `const NAME = value;`.
+func (ctx *phpContext) handleConstDeclaration(node *sitter.Node, namespace string) {
+	doc := findDocComment(node, ctx.src)
+	for _, elem := range childrenByType(node, "const_element") {
+		nameNode := childByType(elem, "name")
+		name := nodeText(nameNode, ctx.src)
+		if name == "" {
+			continue
+		}
+		sym := &model.Symbol{
+			ID:        "const:" + qualifyPHP(namespace, name),
+			Name:      name,
+			Kind:      model.KindConstant,
+			Language:  "php",
+			Namespace: namespace,
+			Doc:       doc,
+			Location: model.SourceLocation{
+				File:      ctx.file,
+				StartLine: startLine(elem),
+				EndLine:   endLine(elem),
+			},
+		}
+		if value := elem.NamedChild(int(elem.NamedChildCount()) - 1); value != nil && value != nameNode {
+			sym.Value = nodeText(value, ctx.src)
+		}
+		sym.Type, _ = ParseVar(doc)
+		addGlobalSymbol(sym, ctx.reg)
+	}
+}
+
+// handleGlobalAssignment registers a file-scope `$name = value;` or
+// `$GLOBALS['name'] = value;` statement as a global variable.
+func (ctx *phpContext) handleGlobalAssignment(node *sitter.Node) {
+	if node.Type() != "expression_statement" || node.NamedChildCount() == 0 {
+		return
+	}
+	assign := node.NamedChild(0)
+	if assign.Type() != "assignment_expression" && assign.Type() != "reference_assignment_expression" {
+		return
+	}
+	name := globalVariableName(assign.ChildByFieldName("left"), ctx.src)
+	if name == "" {
+		return
+	}
+
+	doc := findDocComment(node, ctx.src)
+	sym := &model.Symbol{
+		ID:       "global:" + name,
+		Name:     "$" + name,
+		Kind:     model.KindGlobal,
+		Language: "php",
+		Doc:      doc,
+		Location: model.SourceLocation{
+			File:      ctx.file,
+			StartLine: startLine(node),
+			EndLine:   endLine(node),
+		},
+	}
+	right := assign.ChildByFieldName("right")
+	sym.Value = nodeText(right, ctx.src)
+
+	// Type: @global or @var in the docblock, else the class of `new X()`.
+	for _, tag := range doc.Globals {
+		if tag.Name == name {
+			sym.Type = tag.Type
+		}
+	}
+	if sym.Type == "" {
+		sym.Type, _ = ParseVar(doc)
+	}
+	if sym.Type == "" && right != nil && right.Type() == "object_creation_expression" {
+		for i := 0; i < int(right.NamedChildCount()); i++ {
+			if t := right.NamedChild(i).Type(); t == "name" || t == "qualified_name" {
+				sym.Type = phpClassName(right.NamedChild(i), ctx.src)
+				break
+			}
+		}
+	}
+	addGlobalSymbol(sym, ctx.reg)
+}
+
+// globalVariableName returns the name assigned by `$name` or `$GLOBALS['name']`.
+func globalVariableName(left *sitter.Node, src []byte) string {
+	if left == nil {
+		return ""
+	}
+	switch left.Type() {
+	case "variable_name":
+		name := strings.TrimPrefix(nodeText(left, src), "$")
+		if name == "GLOBALS" || name == "this" {
+			return ""
+		}
+		return name
+	case "subscript_expression":
+		if left.NamedChildCount() == 2 && nodeText(left.NamedChild(0), src) == "$GLOBALS" && left.NamedChild(1).Type() == "string" {
+			return unquotePHP(nodeText(left.NamedChild(1), src))
+		}
+	}
+	return ""
+}
+
+// registerDocGlobals records the globals a function declares with @global
+// tags, creating the global's symbol if no file-scope assignment defines it,
+// and links the function to it via Uses. A mention has no source location of
+// its own, as the global isn't defined in the function's file; its type and
+// description are filled in from the @global tags by the resolver, so they
+// don't depend on which file was parsed first.
+func registerDocGlobals(sym *model.Symbol, reg *model.Registry) {
+	for _, tag := range sym.Doc.Globals {
+		global := &model.Symbol{
+			ID:       "global:" + tag.Name,
+			Name:     "$" + tag.Name,
+			Kind:     model.KindGlobal,
+			Language: "php",
+		}
+		addGlobalSymbol(global, reg)
+		sym.Uses = appendUnique(sym.Uses, global.ID)
+	}
+}
+
+// addGlobalSymbol adds a constant or global, or merges it with an existing
+// definition. A real definition (define, const or assignment) beats an
+// @global mention, a documented one beats an undocumented one, and ties go
+// to the earliest file/line so the result doesn't depend on parse order.
+func addGlobalSymbol(sym *model.Symbol, reg *model.Registry) {
+	reg.AddOrMerge(sym, func(existing *model.Symbol) {
+		overridable := sym.IsOverridable || existing.IsOverridable
+		if globalOutranks(sym, existing) {
+			*existing = *sym
+		}
+		existing.IsOverridable = overridable
+	})
+}
+
+// globalOutranks reports whether a should replace b as the canonical
+// definition.
+func globalOutranks(a, b *model.Symbol) bool {
+	if ra, rb := globalRank(a), globalRank(b); ra != rb {
+		return ra > rb
+	}
+	if a.Location.File != b.Location.File {
+		return a.Location.File < b.Location.File
+	}
+	return a.Location.StartLine < b.Location.StartLine
+}
+
+func globalRank(sym *model.Symbol) int {
+	rank := 0
+	if sym.Value != "" || sym.Kind == model.KindConstant {
+		rank += 2 // Defined here rather than just mentioned in an @global tag
+	}
+	if hookDocRank(sym.Doc) > 0 {
+		rank++
+	}
+	return rank
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"regexp"
 	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
@@ -10,97 +11,376 @@ import (
 
 // WordPress hook functions we detect.
 var hookFunctions = map[string]model.HookType{
-	"do_action":               model.HookAction,
-	"do_action_ref_array":     model.HookAction,
-	"apply_filters":           model.HookFilter,
-	"apply_filters_ref_array": model.HookFilter,
+	"do_action":                model.HookAction,
+	"do_action_ref_array":      model.HookAction,
+	"apply_filters":            model.HookFilter,
+	"apply_filters_ref_array":  model.HookFilter,
+	"do_action_deprecated":     model.HookAction,
+	"apply_filters_deprecated": model.HookFilter,
 }
 
-// scanForHooks walks the AST subtree looking for WordPress hook calls.
-func scanForHooks(bodyNode *sitter.Node, src []byte, file string, callerID string, reg *model.Registry) {
+// duplicateHookRegex matches the summary of a docblock that only points at
+// the canonical documentation of a hook fired in several places.
+var duplicateHookRegex = regexp.MustCompile(`(?i)^This (?:filter|action|hook) is documented in\s+(\S+)`)
+
+// WordPress hook registration and query functions we detect. These bind
+// callbacks to a hook (or inspect it) rather than firing it.
+var hookRegistrationFunctions = map[string]bool{
+	"add_action":    true,
+	"add_filter":    true,
+	"remove_action": true,
+	"remove_filter": true,
+	"has_action":    true,
+	"has_filter":    true,
+	"did_action":    true,
+	"did_filter":    true,
+}
+
+// Object registration functions whose first argument names a post type,
+// status or taxonomy. Used to list concrete expansions of dynamic hooks.
+var objectRegistrationFunctions = map[string]string{
+	"register_post_type":   "post_type",
+	"register_post_status": "post_status",
+	"register_taxonomy":    "taxonomy",
+}
+
+// scanForHooks walks the AST subtree looking for WordPress hook calls. The
+// caller is scanned before it is added to the registry, so the hooks it binds
+// are recorded on this declaration rather than on whichever declaration of
+// its ID the registry holds.
+func scanForHooks(bodyNode *sitter.Node, src []byte, file, namespace string, caller *model.Symbol, reg *model.Registry) {
 	walkTree(bodyNode, func(node *sitter.Node) {
-		if node.Type() != "function_call_expression" {
-			return
-		}
-		fnNode := node.ChildByFieldName("function")
-		if fnNode == nil {
-			return
-		}
-		fnName := nodeText(fnNode, src)
-		hookType, isHook := hookFunctions[fnName]
-		if !isHook {
-			return
-		}
-		registerHook(node, hookType, callerID, src, file, reg)
+		visitHookCall(node, src, file, namespace, caller, reg)
 	})
 }
 
-// registerHook extracts the hook tag and creates a hook symbol.
-func registerHook(call *sitter.Node, hookType model.HookType, callerID string, src []byte, file string, reg *model.Registry) {
-	args := call.ChildByFieldName("arguments")
-	if args == nil || args.NamedChildCount() == 0 {
-		return
+// scanFileScopeHooks looks for hook calls in file-scope code such as
+// wp-includes/default-filters.php, skipping nested declarations (their
+// bodies are scanned when the declaration itself is handled).
+func scanFileScopeHooks(node *sitter.Node, src []byte, file, namespace string, reg *model.Registry) {
+	walkTreePruned(node, isPHPDeclaration, func(n *sitter.Node) {
+		visitHookCall(n, src, file, namespace, nil, reg)
+	})
+}
+
+func isPHPDeclaration(node *sitter.Node) bool {
+	switch node.Type() {
+	case "function_definition", "class_declaration", "interface_declaration",
+		"trait_declaration", "enum_declaration", "method_declaration":
+		return true
 	}
+	return false
+}
 
-	// First argument is the hook tag
-	firstArg := args.NamedChild(0)
-	// If wrapped in an argument node, unwrap it
-	if firstArg.Type() == "argument" {
-		if firstArg.NamedChildCount() > 0 {
-			firstArg = firstArg.NamedChild(0)
+// visitHookCall dispatches a single node if it is a hook firing or registration
+// call, or a define() of a global constant. caller is nil at file scope.
+func visitHookCall(node *sitter.Node, src []byte, file, namespace string, caller *model.Symbol, reg *model.Registry) {
+	if node.Type() != "function_call_expression" {
+		return
+	}
+	callerID := ""
+	if caller != nil {
+		callerID = caller.ID
+	}
+	fnNode := node.ChildByFieldName("function")
+	if fnNode == nil {
+		return
+	}
+	fnName := strings.TrimPrefix(nodeText(fnNode, src), "\\")
+	if hookType, isHook := hookFunctions[fnName]; isHook {
+		registerHook(node, hookType, callerID, src, file, reg)
+		if _, deprecated := deprecationFunctions[fnName]; deprecated {
+			registerDeprecation(node, fnName, callerID, src, file, reg)
 		}
+		return
 	}
-
-	tag := extractHookTag(firstArg, src)
-	if tag == "" {
+	if _, ok := deprecationFunctions[fnName]; ok {
+		registerDeprecation(node, fnName, callerID, src, file, reg)
 		return
 	}
+	if hookRegistrationFunctions[fnName] {
+		registerHookCallback(node, fnName, namespace, caller, src, file, reg)
+		return
+	}
+	if category, ok := objectRegistrationFunctions[fnName]; ok {
+		registerObjectName(node, category, src, reg)
+		return
+	}
+	if fnName == "define" {
+		registerDefine(node, src, file, reg)
+	}
+}
 
-	hookID := "hook:" + tag
+// registerObjectName records the name passed to register_post_type() and
+// friends, so dynamic hooks like save_post_{$post->post_type} can be expanded.
+func registerObjectName(call *sitter.Node, category string, src []byte, reg *model.Registry) {
+	args := callArguments(call)
+	if len(args) == 0 || args[0].Type() != "string" {
+		return
+	}
+	if name := unquotePHP(nodeText(args[0], src)); name != "" {
+		reg.AddRegisteredName(category, name)
+	}
+}
 
-	// Check if hook already registered (hooks can be fired from multiple places)
-	existing := reg.Get(hookID)
-	if existing != nil {
-		existing.CallSites = append(existing.CallSites, callerID)
+// registerHook records a hook firing as a call site on the hook symbol,
+// creating the symbol on first sight.
+func registerHook(call *sitter.Node, hookType model.HookType, callerID string, src []byte, file string, reg *model.Registry) {
+	fnName := strings.TrimPrefix(nodeText(call.ChildByFieldName("function"), src), "\\")
+	args := callArguments(call)
+	if len(args) == 0 {
 		return
 	}
 
-	// Extract doc block from the hook call site
-	doc := findDocComment(call, src)
+	// First argument is the hook tag
+	tag := extractHookTag(args[0], src)
+	if tag == "" {
+		return
+	}
 
-	// Build params from the doc's @param tags
-	var params []model.Param
-	for _, raw := range doc.Tags["param"] {
-		if m := paramRegex.FindStringSubmatch("@param " + raw); m != nil {
-			params = append(params, model.Param{
-				Type:        m[1],
-				Name:        strings.TrimPrefix(m[2], "$"),
-				Description: strings.TrimSpace(m[3]),
-			})
-		}
+	site := model.CallSite{
+		CallerID: callerID,
+		File:     file,
+		Line:     startLine(call),
+		Function: fnName,
+		Doc:      hookDocComment(call, src),
+	}
+	site.DocumentedIn = duplicateHookFile(site.Doc)
+	for _, arg := range args[1:] {
+		site.Args = append(site.Args, nodeText(arg, src))
 	}
 
 	sym := &model.Symbol{
-		ID:        hookID,
+		ID:        "hook:" + tag,
 		Name:      tag,
 		Kind:      model.KindHook,
 		Language:  "php",
-		HookType:  hookType,
 		HookTag:   tag,
-		Doc:       doc,
-		Params:    params,
-		CallSites: []string{callerID},
-		Location: model.SourceLocation{
-			File:      file,
-			StartLine: startLine(call),
-			EndLine:   endLine(call),
-		},
+		IsDynamic: isDynamicHookTag(tag),
+		CallSites: []model.CallSite{site},
 	}
-	reg.Add(sym)
+	applyHookDoc(sym, site, hookType, call)
+
+	// Hooks can be fired from many places. Keep every call site, and take the
+	// documentation from the best one so the result doesn't depend on which
+	// worker parsed which file first.
+	reg.AddOrMerge(sym, func(existing *model.Symbol) {
+		existing.CallSites = append(existing.CallSites, site)
+		if betterHookDoc(site, existing) {
+			applyHookDoc(existing, site, hookType, call)
+		}
+	})
+}
+
+// applyHookDoc makes site the documented call site of a hook symbol.
+func applyHookDoc(sym *model.Symbol, site model.CallSite, hookType model.HookType, call *sitter.Node) {
+	sym.HookType = hookType
+	sym.Doc = site.Doc
+	sym.Params = ParseParams(site.Doc)
+	sym.Location = model.SourceLocation{
+		File:      site.File,
+		StartLine: startLine(call),
+		EndLine:   endLine(call),
+	}
+}
+
+// betterHookDoc reports whether site should replace the hook's current
+// documented call site. A full docblock beats a "This filter is documented
+// in ..." pointer, which beats no docblock; ties go to the earliest file/line.
+func betterHookDoc(site model.CallSite, hook *model.Symbol) bool {
+	newRank, curRank := hookDocRank(site.Doc), hookDocRank(hook.Doc)
+	if newRank != curRank {
+		return newRank > curRank
+	}
+	if site.File != hook.Location.File {
+		return site.File < hook.Location.File
+	}
+	return site.Line < hook.Location.StartLine
+}
+
+func hookDocRank(doc model.DocBlock) int {
+	switch {
+	case doc.Summary == "" && len(doc.Tags) == 0:
+		return 0
+	case isDuplicateHookDoc(doc):
+		return 1
+	default:
+		return 2
+	}
+}
+
+// isDuplicateHookDoc reports whether a docblock is WordPress's pointer to the
+// canonical documentation of a repeated hook, e.g.
+// "/** This filter is documented in wp-includes/post.php */".
+func isDuplicateHookDoc(doc model.DocBlock) bool {
+	return duplicateHookFile(doc) != ""
+}
+
+// duplicateHookFile returns the file referenced by a duplicate-hook comment,
+// or "" if the docblock is not one.
+func duplicateHookFile(doc model.DocBlock) string {
+	m := duplicateHookRegex.FindStringSubmatch(doc.Summary)
+	if m == nil {
+		return ""
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(m[1], "/"), ".")
+}
+
+// hookDocComment finds the docblock for a hook call. WordPress places it before
+// the enclosing statement (`return apply_filters( ... )`, `$x = apply_filters( ... )`),
+// so search from the nearest statement rather than the call itself.
+func hookDocComment(call *sitter.Node, src []byte) model.DocBlock {
+	node := call
+	for parent := call.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Type() == "compound_statement" || parent.Type() == "program" {
+			break
+		}
+		node = parent
+		if strings.HasSuffix(parent.Type(), "_statement") {
+			break
+		}
+	}
+	return findDocComment(node, src)
+}
+
+// registerHookCallback records an add_action/add_filter style call against its
+// hook tag and links the enclosing declaration to the hook via Uses.
+func registerHookCallback(call *sitter.Node, fnName, namespace string, caller *model.Symbol, src []byte, file string, reg *model.Registry) {
+	args := callArguments(call)
+	if len(args) == 0 {
+		return
+	}
+	tag := extractHookTag(args[0], src)
+	if tag == "" {
+		return
+	}
+	callerID := ""
+	if caller != nil {
+		callerID = caller.ID
+	}
+
+	cb := model.HookCallback{
+		Tag:      tag,
+		Function: fnName,
+		CallerID: callerID,
+		Language: "php",
+		File:     file,
+		Line:     startLine(call),
+	}
+
+	switch fnName {
+	case "add_action", "add_filter":
+		cb.Priority = "10"
+		cb.AcceptedArgs = "1"
+	case "remove_action", "remove_filter":
+		cb.Priority = "10"
+	}
+	if fnName != "did_action" && fnName != "did_filter" {
+		if len(args) > 1 {
+			cb.Callback = hookCallbackName(args[1], src, namespace, callerID)
+		}
+		if len(args) > 2 {
+			cb.Priority = nodeText(args[2], src)
+		}
+		if len(args) > 3 && cb.AcceptedArgs != "" {
+			cb.AcceptedArgs = nodeText(args[3], src)
+		}
+	}
+	reg.AddHookCallback(cb)
+
+	if caller != nil {
+		caller.Uses = appendUnique(caller.Uses, "hook:"+tag)
+	}
+}
+
+// hookCallbackName renders a PHP callable expression in a resolvable form:
+// 'my_func', array( $this, 'method' ) and 'Class::method' become "my_func" and
+// "Class::method"; closures become "{closure}". String callables are fully
+// qualified at runtime, and __NAMESPACE__ . '\\my_func' is qualified with
+// the namespace the call is made in. Anything else is returned as written in
+// the source.
+func hookCallbackName(node *sitter.Node, src []byte, namespace, callerID string) string {
+	switch node.Type() {
+	case "string", "encapsed_string":
+		return strings.TrimPrefix(unescapePHP(nodeText(node, src)), "\\")
+	case "binary_expression":
+		if name, ok := namespacedName(node, src, namespace); ok {
+			return name
+		}
+	case "array_creation_expression":
+		elems := childrenByType(node, "array_element_initializer")
+		if len(elems) != 2 || elems[0].NamedChildCount() == 0 || elems[1].NamedChildCount() == 0 {
+			break
+		}
+		class := callbackClass(elems[0].NamedChild(0), src, namespace, callerID)
+		method := unquotePHP(nodeText(elems[1].NamedChild(0), src))
+		return class + "::" + method
+	case "anonymous_function_creation_expression", "arrow_function":
+		return "{closure}"
+	}
+	return nodeText(node, src)
+}
+
+// namespacedName folds a `__NAMESPACE__ . '\\Name'` expression into the
+// fully qualified name it evaluates to.
+func namespacedName(node *sitter.Node, src []byte, namespace string) (string, bool) {
+	left, right := node.ChildByFieldName("left"), node.ChildByFieldName("right")
+	if left == nil || right == nil || nodeText(left, src) != "__NAMESPACE__" {
+		return "", false
+	}
+	if right.Type() != "string" && right.Type() != "encapsed_string" {
+		return "", false
+	}
+	name := strings.TrimPrefix(unescapePHP(nodeText(right, src)), "\\")
+	if namespace == "" {
+		return name, true
+	}
+	return namespace + "\\" + name, true
+}
+
+// callbackClass resolves the object/class half of an array callable.
+func callbackClass(node *sitter.Node, src []byte, namespace, callerID string) string {
+	enclosing := ""
+	if idx := strings.LastIndex(callerID, "::"); idx >= 0 {
+		enclosing = callerID[:idx]
+	}
+
+	text := strings.TrimPrefix(nodeText(node, src), "&")
+	switch {
+	case text == "$this" || text == "__CLASS__" || text == "self::class" || text == "static::class":
+		if enclosing != "" {
+			return enclosing
+		}
+	case node.Type() == "string":
+		return strings.TrimPrefix(unescapePHP(text), "\\")
+	case node.Type() == "binary_expression":
+		if name, ok := namespacedName(node, src, namespace); ok {
+			return name
+		}
+	case strings.HasSuffix(text, "::class"):
+		return strings.TrimPrefix(strings.TrimSuffix(text, "::class"), "\\")
+	}
+	return text
+}
+
+// unquotePHP strips surrounding single or double quotes from a PHP string literal.
+func unquotePHP(s string) string {
+	return strings.Trim(s, "'\"")
+}
+
+// phpEscapes unescapes the backslashes and quotes of a PHP string literal.
+var phpEscapes = strings.NewReplacer(`\\`, `\`, `\'`, `'`, `\"`, `"`)
+
+// unescapePHP returns the value of a PHP string literal that has no
+// interpolation, such as 'My\\Plugin\\init'.
+func unescapePHP(s string) string {
+	return phpEscapes.Replace(unquotePHP(s))
 }
 
 // extractHookTag resolves the hook tag string from the AST node.
-// Handles simple strings, concatenation, and variable interpolation.
+// Handles simple strings, concatenation, and variable interpolation. Dynamic
+// parts keep their original expression as a named placeholder, so
+// 'save_post_' . $post->post_type becomes "save_post_{$post->post_type}".
 func extractHookTag(node *sitter.Node, src []byte) string {
 	if node == nil {
 		return ""
@@ -118,10 +398,10 @@ func extractHookTag(node *sitter.Node, src []byte) string {
 		for i := 0; i < int(node.NamedChildCount()); i++ {
 			child := node.NamedChild(i)
 			switch child.Type() {
-			case "string_content", "string_value":
+			case "string_content", "string_value", "escape_sequence":
 				parts = append(parts, nodeText(child, src))
 			default:
-				parts = append(parts, "{$var}")
+				parts = append(parts, hookPlaceholder(child, src))
 			}
 		}
 		if len(parts) == 0 {
@@ -140,10 +420,10 @@ func extractHookTag(node *sitter.Node, src []byte) string {
 		rightStr := extractHookTag(right, src)
 		if leftStr != "" || rightStr != "" {
 			if leftStr == "" {
-				leftStr = "{$var}"
+				leftStr = hookPlaceholder(left, src)
 			}
 			if rightStr == "" {
-				rightStr = "{$var}"
+				rightStr = hookPlaceholder(right, src)
 			}
 			return leftStr + rightStr
 		}
@@ -151,3 +431,14 @@ func extractHookTag(node *sitter.Node, src []byte) string {
 
 	return ""
 }
+
+// hookPlaceholder renders a dynamic part of a hook name as "{expression}",
+// matching how WordPress itself documents dynamic hooks.
+func hookPlaceholder(node *sitter.Node, src []byte) string {
+	return "{" + nodeText(node, src) + "}"
+}
+
+// isDynamicHookTag reports whether a tag produced by extractHookTag contains placeholders.
+func isDynamicHookTag(tag string) bool {
+	return strings.Contains(tag, "{")
+}
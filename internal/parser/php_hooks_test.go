@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/smacker/go-tree-sitter/php"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestHookCallbackName(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{
+			name: "function names",
+			src:  `add_action( 'init', 'my_init' ); add_filter( 'the_title', '\my_title' );`,
+			want: []string{"my_init", "my_title"},
+		},
+		{
+			name: "escaped namespaced strings",
+			src:  `add_action( 'init', 'My\\Plugin\\boot' ); add_action( 'init', "My\\Plugin\\Loader::run" );`,
+			want: []string{`My\Plugin\boot`, `My\Plugin\Loader::run`},
+		},
+		{
+			name: "__NAMESPACE__ concatenation",
+			src: `namespace My\Plugin;
+add_action( 'init', __NAMESPACE__ . '\\on_init' );
+add_action( 'init', __NAMESPACE__ . '\on_init' );
+add_action( 'init', array( __NAMESPACE__ . '\\Loader', 'run' ) );`,
+			want: []string{`My\Plugin\on_init`, `My\Plugin\on_init`, `My\Plugin\Loader::run`},
+		},
+		{
+			name: "__NAMESPACE__ in the global namespace",
+			src:  `add_action( 'init', __NAMESPACE__ . '\\on_init' );`,
+			want: []string{"on_init"},
+		},
+		{
+			name: "array callables",
+			src: `namespace App;
+class Loader {
+	public function boot() {
+		add_action( 'init', array( $this, 'run' ) );
+		add_action( 'init', [ __CLASS__, 'setup' ] );
+		add_action( 'init', [ 'Other\\Thing', 'go' ] );
+	}
+}`,
+			want: []string{`App\Loader::run`, `App\Loader::setup`, `Other\Thing::go`},
+		},
+		{
+			name: "class constant",
+			src:  `add_action( 'init', [ \WP_Thing::class, 'go' ] );`,
+			want: []string{"WP_Thing::go"},
+		},
+		{
+			name: "closures and variables",
+			src:  `add_action( 'init', function () {} ); add_action( 'init', fn() => 1 ); add_action( 'init', $cb );`,
+			want: []string{"{closure}", "{closure}", "$cb"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg := extractPHPSource(t, "plugin.php", "<?php\n"+tt.src)
+			var got []string
+			for _, cb := range reg.HookCallbacks() {
+				got = append(got, cb.Callback)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("callbacks = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractHookTag(t *testing.T) {
+	tests := []struct {
+		call    string
+		tag     string
+		dynamic bool
+	}{
+		{`do_action( 'init' );`, "init", false},
+		{`do_action( "wp_loaded" );`, "wp_loaded", false},
+		{`apply_filters( "pre_option_{$option}", false );`, "pre_option_{$option}", true},
+		{`do_action( "save_post_{$post->post_type}", $post_id );`, "save_post_{$post->post_type}", true},
+		{`do_action( 'save_post_' . $post->post_type, $post_id );`, "save_post_{$post->post_type}", true},
+		{`apply_filters( "{$adjacent}_post_link", $output );`, "{$adjacent}_post_link", true},
+		{`do_action( $prefix . '_loaded' . $suffix );`, "{$prefix}_loaded{$suffix}", true},
+		{`do_action_ref_array( 'pre_get_posts', array( &$this ) );`, "pre_get_posts", false},
+		{`do_action( $hook_name );`, "", false},
+	}
+	for _, tt := range tests {
+		reg := extractPHPSource(t, "hooks.php", "<?php\n"+tt.call)
+		hooks := reg.ByKind("hook")
+		if tt.tag == "" {
+			if len(hooks) != 0 {
+				t.Errorf("%s: registered hook %s for a variable tag", tt.call, hooks[0].ID)
+			}
+			continue
+		}
+		if len(hooks) != 1 {
+			t.Errorf("%s: got %d hooks, want 1", tt.call, len(hooks))
+			continue
+		}
+		if hooks[0].HookTag != tt.tag || hooks[0].IsDynamic != tt.dynamic {
+			t.Errorf("%s: tag %q (dynamic %v), want %q (dynamic %v)", tt.call, hooks[0].HookTag, hooks[0].IsDynamic, tt.tag, tt.dynamic)
+		}
+	}
+}
+
+func TestHookCallSites(t *testing.T) {
+	reg := model.NewRegistry()
+	files := []struct{ name, src string }{
+		{"b.php", `<?php
+function b() {
+	/** This filter is documented in wp-includes/a.php */
+	$title = apply_filters( 'the_title', $title, $id );
+}`},
+		{"a.php", `<?php
+function a() {
+	$title = apply_filters( 'the_title', $title );
+
+	/**
+	 * Filters the post title.
+	 *
+	 * @param string $title The post title.
+	 * @param int    $id    The post ID.
+	 */
+	return apply_filters( 'the_title', $title, $id );
+}`},
+	}
+	for _, f := range files {
+		extractPHP(parseTree(t, php.GetLanguage(), f.src), []byte(f.src), f.name, reg)
+	}
+
+	hook := reg.Get("hook:the_title")
+	if hook == nil {
+		t.Fatal("hook not extracted")
+	}
+	if hook.Location.File != "a.php" || hook.Location.StartLine != 11 || hook.Doc.Summary != "Filters the post title." {
+		t.Errorf("documented at %s:%d (%q), want a.php:11", hook.Location.File, hook.Location.StartLine, hook.Doc.Summary)
+	}
+	if len(hook.Params) != 2 || hook.HookType != model.HookFilter {
+		t.Errorf("got %d params and type %q, want 2 params of a filter", len(hook.Params), hook.HookType)
+	}
+
+	var sites []string
+	for _, site := range hook.CallSites {
+		sites = append(sites, fmt.Sprintf("%s:%d %s %s %s", site.File, site.Line, site.CallerID, strings.Join(site.Args, ","), site.DocumentedIn))
+	}
+	want := []string{
+		"b.php:4 b $title,$id wp-includes/a.php",
+		"a.php:3 a $title ",
+		"a.php:11 a $title,$id ",
+	}
+	if !reflect.DeepEqual(sites, want) {
+		t.Errorf("call sites = %q, want %q", sites, want)
+	}
+}
@@ -0,0 +1,80 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestMemberNamespaces(t *testing.T) {
+	reg := extractPHPSource(t, "members.php", `<?php
+namespace Foo\Bar {
+	class C {
+		const X = 1;
+		public $p;
+		public function m() {}
+	}
+	enum E {
+		case A;
+		const Y = 2;
+	}
+}
+namespace Baz {
+	trait T { public static $q; }
+}
+`)
+	tests := []struct {
+		id, namespace string
+	}{
+		{`Foo\Bar\C`, `Foo\Bar`},
+		{`Foo\Bar\C::X`, `Foo\Bar`},
+		{`Foo\Bar\C::$p`, `Foo\Bar`},
+		{`Foo\Bar\C::m`, `Foo\Bar`},
+		{`Foo\Bar\E::A`, `Foo\Bar`},
+		{`Foo\Bar\E::Y`, `Foo\Bar`},
+		{`Baz\T::$q`, "Baz"},
+	}
+	for _, tt := range tests {
+		sym := reg.Get(tt.id)
+		if sym == nil {
+			t.Errorf("%s not extracted", tt.id)
+			continue
+		}
+		if sym.Namespace != tt.namespace {
+			t.Errorf("%s namespace = %q, want %q", tt.id, sym.Namespace, tt.namespace)
+		}
+	}
+}
+
+func TestUseImports(t *testing.T) {
+	reg := extractPHPSource(t, "imports.php", `<?php
+namespace App;
+use Vendor\Lib\Client, Vendor\Lib\Server as Srv;
+use function Vendor\Lib\fetch;
+use const Vendor\Lib\VERSION;
+use Vendor\Http\{Request, Response as Res, function send};
+`)
+	imports := reg.Imports("imports.php", "App")
+	if imports == nil {
+		t.Fatal("no imports recorded")
+	}
+	want := model.PHPImports{
+		Classes: map[string]string{
+			"client":  `Vendor\Lib\Client`,
+			"srv":     `Vendor\Lib\Server`,
+			"request": `Vendor\Http\Request`,
+			"res":     `Vendor\Http\Response`,
+		},
+		Functions: map[string]string{
+			"fetch": `Vendor\Lib\fetch`,
+			"send":  `Vendor\Http\send`,
+		},
+		Constants: map[string]string{
+			"VERSION": `Vendor\Lib\VERSION`,
+		},
+	}
+	if !reflect.DeepEqual(*imports, want) {
+		t.Errorf("imports = %+v, want %+v", *imports, want)
+	}
+}
@@ -0,0 +1,65 @@
+package parser
+
+import (
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// phpType converts a type node from the AST into a structured type. ?T becomes
+// the union T|null, and nested unions are flattened. Returns nil for a nil node.
+func phpType(node *sitter.Node, src []byte) *model.TypeExpr {
+	if node == nil {
+		return nil
+	}
+	switch node.Type() {
+	case "optional_type":
+		if node.NamedChildCount() == 0 {
+			return nil
+		}
+		inner := phpType(node.NamedChild(0), src)
+		if inner == nil {
+			return nil
+		}
+		t := &model.TypeExpr{Kind: model.TypeUnion}
+		t.Types = append(inner.Members(), model.TypeExpr{Kind: model.TypeNamed, Name: "null"})
+		return t
+
+	case "union_type", "disjunctive_normal_form_type":
+		t := &model.TypeExpr{Kind: model.TypeUnion}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			if member := phpType(node.NamedChild(i), src); member != nil {
+				t.Types = append(t.Types, member.Members()...)
+			}
+		}
+		return t
+
+	case "intersection_type":
+		t := &model.TypeExpr{Kind: model.TypeIntersection}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			if member := phpType(node.NamedChild(i), src); member != nil {
+				t.Types = append(t.Types, *member)
+			}
+		}
+		return t
+
+	default:
+		// named_type, primitive_type, bottom_type (never), ...
+		return &model.TypeExpr{Kind: model.TypeNamed, Name: nodeText(node, src)}
+	}
+}
+
+// phpReturn combines the @return tag with the native return type. The
+// documented type is kept for display since it is usually more precise.
+func phpReturn(node *sitter.Node, src []byte, doc model.DocBlock) *model.ReturnValue {
+	ret := ParseReturn(doc)
+	native := phpType(node.ChildByFieldName("return_type"), src)
+	if native == nil {
+		return ret
+	}
+	if ret == nil {
+		ret = &model.ReturnValue{Type: native.String()}
+	}
+	ret.Native = native
+	return ret
+}
@@ -0,0 +1,122 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestExtractPHPParamTypes(t *testing.T) {
+	reg := extractPHPSource(t, "types.php", `<?php
+/**
+ * @param WP_Post[]   $posts    Posts.
+ * @param ?string     $title    Title.
+ * @param int         $count    Count.
+ * @param             $untyped  Untyped.
+ * @param array       $args     Arguments.
+ */
+function f( array $posts, string $title, ?int $count, $untyped, (A&B)|null $both, $args = array() ) {}
+`)
+	f := reg.Get("f")
+	if f == nil {
+		t.Fatal("function not extracted")
+	}
+
+	tests := []struct {
+		name, typ, native, docType string
+		nullable                   bool
+	}{
+		{name: "posts", typ: "array", native: "array", docType: "WP_Post[]"},
+		{name: "title", typ: "string", native: "string", docType: "string", nullable: true},
+		{name: "count", typ: "int|null", native: "int|null", docType: "int", nullable: true},
+		{name: "untyped"},
+		{name: "both", typ: "(A&B)|null", native: "(A&B)|null", nullable: true},
+		{name: "args", typ: "array", docType: "array"},
+	}
+	if len(f.Params) != len(tests) {
+		t.Fatalf("got %d params, want %d", len(f.Params), len(tests))
+	}
+	for i, tt := range tests {
+		p := f.Params[i]
+		native := ""
+		if p.Native != nil {
+			native = p.Native.String()
+		}
+		if p.Name != tt.name || p.Type != tt.typ || native != tt.native || p.DocType != tt.docType || p.IsNullable != tt.nullable {
+			t.Errorf("param %d = {%s type=%q native=%q doc=%q nullable=%v}, want {%s type=%q native=%q doc=%q nullable=%v}",
+				i, p.Name, p.Type, native, p.DocType, p.IsNullable, tt.name, tt.typ, tt.native, tt.docType, tt.nullable)
+		}
+	}
+}
+
+func TestPHPReturnType(t *testing.T) {
+	reg := extractPHPSource(t, "types.php", `<?php
+/** @return WP_Post|null The post. */
+function documented(): ?WP_Post {}
+function native(): static|false {}
+/** @return string */
+function doc_only() {}
+`)
+	tests := []struct {
+		id, typ, native string
+	}{
+		{"documented", "WP_Post|null", "WP_Post|null"},
+		{"native", "static|false", "static|false"},
+		{"doc_only", "string", ""},
+	}
+	for _, tt := range tests {
+		sym := reg.Get(tt.id)
+		if sym == nil || sym.Returns == nil {
+			t.Errorf("%s: no return value", tt.id)
+			continue
+		}
+		native := ""
+		if sym.Returns.Native != nil {
+			native = sym.Returns.Native.String()
+		}
+		if sym.Returns.Type != tt.typ || native != tt.native {
+			t.Errorf("%s returns {type=%q native=%q}, want {type=%q native=%q}", tt.id, sym.Returns.Type, native, tt.typ, tt.native)
+		}
+	}
+}
+
+func TestExtractPHPParamDefaults(t *testing.T) {
+	reg := extractPHPSource(t, "defaults.php", `<?php
+/**
+ * @param string $status Post status. Default 'publish'.
+ * @param int    $limit  Limit. Default 10.
+ * @param array  $args   Arguments. Default empty array.
+ */
+function f( $status = 'draft', $limit = PHP_INT_MAX, $args = array(), $flag = false, ...$rest ) {}
+`)
+	f := reg.Get("f")
+	if f == nil {
+		t.Fatal("function not extracted")
+	}
+	want := []struct {
+		name, def string
+		variadic  bool
+	}{
+		{"status", "'draft'", false},
+		{"limit", "PHP_INT_MAX", false},
+		{"args", "array()", false},
+		{"flag", "false", false},
+		{"rest", "", true},
+	}
+	if len(f.Params) != len(want) {
+		t.Fatalf("got %d params, want %d", len(f.Params), len(want))
+	}
+	for i, w := range want {
+		p := f.Params[i]
+		if p.Name != w.name || p.Default != w.def || p.IsVariadic != w.variadic {
+			t.Errorf("param %d = {%s default=%q variadic=%v}, want {%s default=%q variadic=%v}", i, p.Name, p.Default, p.IsVariadic, w.name, w.def, w.variadic)
+		}
+	}
+
+	// Without an AST default, the docblock's "Default ..." sentence is used.
+	reg = extractPHPSource(t, "defaults.php", `<?php
+/** @param string $status Post status. Default 'publish'. */
+function g( $status ) {}
+`)
+	if g := reg.Get("g"); g == nil || len(g.Params) != 1 || g.Params[0].Default != "'publish'" {
+		t.Errorf("documented default not used: %+v", g)
+	}
+}
@@ -0,0 +1,210 @@
+package resolver
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// phpScope returns the file and namespace that names used in sym are resolved
+// against. Members inherit the namespace of their class.
+func (r *Resolver) phpScope(sym *model.Symbol) (file, namespace string) {
+	namespace = sym.Namespace
+	if namespace == "" && sym.ParentID != "" {
+		if parent := r.registry.Get(sym.ParentID); parent != nil {
+			namespace = parent.Namespace
+		}
+	}
+	return sym.Location.File, namespace
+}
+
+// resolvePHPName applies PHP's name resolution rules to a class, function or
+// constant name used in sym, returning the fully qualified name without a
+// leading backslash:
+//
+//   - \A\B is fully qualified;
+//   - namespace\B is relative to the current namespace;
+//   - A\B resolves its first segment against the class imports, else the
+//     current namespace;
+//   - B resolves against the import table for its kind, else the current
+//     namespace.
+func (r *Resolver) resolvePHPName(sym *model.Symbol, name string, kind model.ImportKind) string {
+	if strings.HasPrefix(name, "\\") {
+		return name[1:]
+	}
+	file, namespace := r.phpScope(sym)
+	if len(name) > 10 && strings.EqualFold(name[:10], "namespace\\") {
+		return qualifyPHP(namespace, name[10:])
+	}
+
+	imports := r.registry.Imports(file, namespace)
+	if first, rest, qualified := strings.Cut(name, "\\"); qualified {
+		if imports != nil {
+			if full, ok := imports.Classes[strings.ToLower(first)]; ok {
+				return full + "\\" + rest
+			}
+		}
+		return qualifyPHP(namespace, name)
+	}
+
+	if imports != nil {
+		var full string
+		var ok bool
+		switch kind {
+		case model.ImportClass:
+			full, ok = imports.Classes[strings.ToLower(name)]
+		case model.ImportFunction:
+			full, ok = imports.Functions[strings.ToLower(name)]
+		case model.ImportConst:
+			full, ok = imports.Constants[name]
+		}
+		if ok {
+			return full
+		}
+	}
+	return qualifyPHP(namespace, name)
+}
+
+// findPHPClass resolves a class, interface, trait or enum name used in sym.
+// Unlike functions, unqualified class names never fall back to the global
+// namespace.
+func (r *Resolver) findPHPClass(sym *model.Symbol, name string) *model.Symbol {
+	if name == "" {
+		return nil
+	}
+	target := r.lookupPHP(r.resolvePHPName(sym, name, model.ImportClass))
+	if target == nil {
+		return nil
+	}
+	switch target.Kind {
+	case model.KindClass, model.KindInterface, model.KindTrait, model.KindEnum:
+		return target
+	}
+	return nil
+}
+
+// findPHPFunction resolves a function name used in sym. Unqualified names fall
+// back to the global function when the namespaced one doesn't exist, as PHP
+// does at runtime.
+func (r *Resolver) findPHPFunction(sym *model.Symbol, name string) *model.Symbol {
+	if target := r.lookupPHP(r.resolvePHPName(sym, name, model.ImportFunction)); target != nil && target.Kind == model.KindFunction {
+		return target
+	}
+	if !strings.Contains(name, "\\") {
+		if target := r.lookupPHP(name); target != nil && target.Kind == model.KindFunction {
+			return target
+		}
+	}
+	return nil
+}
+
+// findPHPReference resolves a docblock reference such as "WP_Query",
+// "WP_Query::query()", "WP_Query::$posts" or "wp_insert_post()" used in sym.
+func (r *Resolver) findPHPReference(sym *model.Symbol, ref string) *model.Symbol {
+	ref = strings.TrimSuffix(ref, "()")
+	if class, member, ok := strings.Cut(ref, "::"); ok {
+		owner := r.findPHPClass(sym, class)
+		if owner == nil {
+			return nil
+		}
+		return r.lookupPHP(owner.ID + "::" + member)
+	}
+	if target := r.findPHPClass(sym, ref); target != nil {
+		return target
+	}
+	return r.findPHPFunction(sym, ref)
+}
+
+// lookupPHP finds a PHP symbol by fully qualified ID. Class and function
+// names are case-insensitive in PHP, so an exact match is tried first, then a
+// case-insensitive one.
+func (r *Resolver) lookupPHP(id string) *model.Symbol {
+	if sym := r.registry.Get(id); sym != nil && sym.Language == "php" {
+		return sym
+	}
+	if r.phpIndex == nil {
+		r.phpIndex = make(map[string]*model.Symbol)
+		all := r.registry.All()
+		sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+		for _, sym := range all {
+			key := strings.ToLower(sym.ID)
+			if _, exists := r.phpIndex[key]; !exists && sym.Language == "php" {
+				r.phpIndex[key] = sym
+			}
+		}
+	}
+	return r.phpIndex[strings.ToLower(id)]
+}
+
+// phpPseudoTypes are type names that never refer to a class.
+var phpPseudoTypes = map[string]bool{
+	"string": true, "int": true, "integer": true, "float": true, "double": true,
+	"bool": true, "boolean": true, "array": true, "callable": true, "iterable": true,
+	"object": true, "mixed": true, "void": true, "null": true, "false": true,
+	"true": true, "never": true, "self": true, "static": true, "parent": true,
+	"resource": true, "scalar": true, "numeric": true, "callable-string": true,
+	"class-string": true, "non-empty-string": true, "list": true, "$this": true,
+}
+
+// resolveTypeRefs resolves the class names in PHP type hints and documented
+// types (parameters, returns, properties, constants, @throws and @global) to
+// symbol IDs.
+func (r *Resolver) resolveTypeRefs() {
+	for _, sym := range r.registry.Declarations() {
+		if sym.Language != "php" {
+			continue
+		}
+		types := []string{sym.Type}
+		for _, p := range sym.Params {
+			types = append(types, p.Type)
+		}
+		if sym.Returns != nil {
+			types = append(types, sym.Returns.Type)
+		}
+		for _, tag := range sym.Doc.Throws {
+			types = append(types, tag.Type)
+		}
+		for _, tag := range sym.Doc.Globals {
+			types = append(types, tag.Type)
+		}
+
+		for _, t := range types {
+			for _, name := range typeNames(t) {
+				if _, done := sym.TypeRefs[name]; done {
+					continue
+				}
+				if target := r.findPHPClass(sym, name); target != nil {
+					if sym.TypeRefs == nil {
+						sym.TypeRefs = make(map[string]string)
+					}
+					sym.TypeRefs[name] = target.ID
+					r.stats.Resolved++
+				}
+			}
+		}
+	}
+}
+
+// typeNames splits a type expression such as "?WP_Post|WP_Error[]" or
+// "array<int, \Foo\Bar>" into the class-like names it mentions.
+func typeNames(t string) []string {
+	var names []string
+	fields := strings.FieldsFunc(t, func(c rune) bool {
+		return strings.ContainsRune("|&?()[]<>{}, :", c)
+	})
+	for _, f := range fields {
+		if f == "" || phpPseudoTypes[strings.ToLower(f)] || strings.ContainsAny(f[:1], "$0123456789'\"") || strings.Contains(f, "-") {
+			continue
+		}
+		names = append(names, f)
+	}
+	return names
+}
+
+func qualifyPHP(namespace, name string) string {
+	if namespace != "" {
+		return namespace + "\\" + name
+	}
+	return name
+}
@@ -0,0 +1,120 @@
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// namesRegistry declares a few namespaced symbols and the imports of
+// plugin.php's App\Admin namespace.
+func namesRegistry() *model.Registry {
+	reg := model.NewRegistry()
+	for _, sym := range []*model.Symbol{
+		{ID: `App\Admin\Screen`, Kind: model.KindClass, Namespace: `App\Admin`},
+		{ID: `App\Admin\Screen::render`, Kind: model.KindMethod, ParentID: `App\Admin\Screen`},
+		{ID: `App\Admin\helper`, Kind: model.KindFunction},
+		{ID: `Vendor\Lib\Client`, Kind: model.KindClass},
+		{ID: `Vendor\Lib\Http\Request`, Kind: model.KindClass},
+		{ID: `Vendor\Lib\fetch`, Kind: model.KindFunction},
+		{ID: "WP_Query", Kind: model.KindClass},
+		{ID: "wp_die", Kind: model.KindFunction},
+		{ID: "Screen", Kind: model.KindFunction},
+		{ID: "screen_js", Kind: model.KindFunction, Language: "js"},
+	} {
+		if sym.Language == "" {
+			sym.Language = "php"
+		}
+		reg.Add(sym)
+	}
+	reg.AddImport("plugin.php", `App\Admin`, model.ImportClass, "Client", `Vendor\Lib\Client`)
+	reg.AddImport("plugin.php", `App\Admin`, model.ImportClass, "Lib", `Vendor\Lib`)
+	reg.AddImport("plugin.php", `App\Admin`, model.ImportFunction, "get", `Vendor\Lib\fetch`)
+	reg.AddImport("plugin.php", `App\Admin`, model.ImportConst, "VERSION", `Vendor\Lib\VERSION`)
+	return reg
+}
+
+func TestResolvePHPName(t *testing.T) {
+	r := New(namesRegistry())
+	inNS := &model.Symbol{Namespace: `App\Admin`, Location: model.SourceLocation{File: "plugin.php"}}
+	member := &model.Symbol{ParentID: `App\Admin\Screen`, Location: model.SourceLocation{File: "plugin.php"}}
+	global := &model.Symbol{Location: model.SourceLocation{File: "functions.php"}}
+
+	tests := []struct {
+		sym  *model.Symbol
+		name string
+		kind model.ImportKind
+		want string
+	}{
+		{inNS, `\WP_Query`, model.ImportClass, "WP_Query"},
+		{inNS, `namespace\Screen`, model.ImportClass, `App\Admin\Screen`},
+		{inNS, `Screen`, model.ImportClass, `App\Admin\Screen`},
+		{inNS, `client`, model.ImportClass, `Vendor\Lib\Client`},
+		{inNS, `Lib\Http\Request`, model.ImportClass, `Vendor\Lib\Http\Request`},
+		{inNS, `Sub\Thing`, model.ImportClass, `App\Admin\Sub\Thing`},
+		{inNS, `GET`, model.ImportFunction, `Vendor\Lib\fetch`},
+		{inNS, `Client`, model.ImportFunction, `App\Admin\Client`},
+		{inNS, `VERSION`, model.ImportConst, `Vendor\Lib\VERSION`},
+		{inNS, `version`, model.ImportConst, `App\Admin\version`},
+		{member, `Client`, model.ImportClass, `Vendor\Lib\Client`},
+		{global, `Client`, model.ImportClass, "Client"},
+	}
+	for _, tt := range tests {
+		if got := r.resolvePHPName(tt.sym, tt.name, tt.kind); got != tt.want {
+			t.Errorf("resolvePHPName(%q, %s) in %q = %q, want %q", tt.name, tt.kind, tt.sym.Namespace, got, tt.want)
+		}
+	}
+}
+
+func TestFindPHPSymbols(t *testing.T) {
+	r := New(namesRegistry())
+	inNS := &model.Symbol{Namespace: `App\Admin`, Location: model.SourceLocation{File: "plugin.php"}}
+
+	id := func(sym *model.Symbol) string {
+		if sym == nil {
+			return ""
+		}
+		return sym.ID
+	}
+	tests := []struct {
+		name string
+		got  *model.Symbol
+		want string
+	}{
+		{"class in namespace", r.findPHPClass(inNS, "screen"), `App\Admin\Screen`},
+		{"imported class", r.findPHPClass(inNS, "Client"), `Vendor\Lib\Client`},
+		{"classes don't fall back to global", r.findPHPClass(inNS, "WP_Query"), ""},
+		{"function in namespace", r.findPHPFunction(inNS, "Helper"), `App\Admin\helper`},
+		{"functions fall back to global", r.findPHPFunction(inNS, "wp_die"), "wp_die"},
+		{"qualified functions don't fall back", r.findPHPFunction(inNS, `Sub\wp_die`), ""},
+		{"function name isn't a class", r.findPHPClass(&model.Symbol{}, "Screen"), ""},
+		{"class name isn't a function", r.findPHPFunction(inNS, "Screen"), "Screen"},
+		{"JS symbols are never PHP", r.lookupPHP("screen_js"), ""},
+		{"reference to a method", r.findPHPReference(inNS, "Screen::render()"), `App\Admin\Screen::render`},
+		{"reference to a function", r.findPHPReference(inNS, "get()"), `Vendor\Lib\fetch`},
+		{"reference to an unknown class", r.findPHPReference(inNS, "Nope::render()"), ""},
+	}
+	for _, tt := range tests {
+		if got := id(tt.got); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTypeNames(t *testing.T) {
+	tests := []struct {
+		typ  string
+		want []string
+	}{
+		{"int|string|null", nil},
+		{"?WP_Post|WP_Error[]", []string{"WP_Post", "WP_Error"}},
+		{`array<int, \Foo\Bar>`, []string{`\Foo\Bar`}},
+		{"class-string|$this|static", nil},
+	}
+	for _, tt := range tests {
+		if got := typeNames(tt.typ); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("typeNames(%q) = %q, want %q", tt.typ, got, tt.want)
+		}
+	}
+}
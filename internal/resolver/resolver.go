@@ -1,6 +1,9 @@
 package resolver
 
 import (
+	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/peter/wpdocs/internal/model"
@@ -12,12 +15,29 @@ type Stats struct {
 	Unresolved   int
 	Inheritance  int
 	HookBindings int
+	CallEdges    int
+}
+
+// Issue is a documentation problem found while resolving cross-references.
+type Issue struct {
+	Kind     string // Short machine-readable category, e.g. "hook-doc-missing"
+	SymbolID string
+	File     string
+	Line     int
+	Message  string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s:%d: [%s] %s: %s", i.File, i.Line, i.Kind, i.SymbolID, i.Message)
 }
 
 // Resolver connects symbols via cross-references, inheritance, and hook bindings.
 type Resolver struct {
 	registry *model.Registry
 	stats    Stats
+	issues   []Issue
+
+	phpIndex map[string]*model.Symbol // Lower-cased ID -> PHP symbol, built on first use
 }
 
 func New(reg *model.Registry) *Resolver {
@@ -26,120 +46,1016 @@ func New(reg *model.Registry) *Resolver {
 
 func (r *Resolver) Stats() Stats { return r.stats }
 
+// Issues returns the documentation problems found by ResolveAll, sorted by location.
+func (r *Resolver) Issues() []Issue {
+	sort.SliceStable(r.issues, func(i, j int) bool {
+		if r.issues[i].File != r.issues[j].File {
+			return r.issues[i].File < r.issues[j].File
+		}
+		return r.issues[i].Line < r.issues[j].Line
+	})
+	return r.issues
+}
+
 // ResolveAll performs all cross-reference resolution passes.
 func (r *Resolver) ResolveAll() {
 	r.resolveInheritance()
+	r.resolveMemberSets()
+	r.resolveGlobals()
+	r.resolveTypeRefs()
+	r.checkTypeMismatches()
+	r.resolveCallGraph()
+	r.resolveHookCallSites()
 	r.resolveHookBindings()
+	r.resolveDynamicHooks()
+	r.resolveDeprecations()
 	r.resolveSeeReferences()
 	r.resolveMethodOverrides()
+	r.reportConflicts()
+}
+
+// reportConflicts reports every declaration of an ID that is shadowed by
+// another declaration of the same ID (see model.Registry.Variants).
+func (r *Resolver) reportConflicts() {
+	for _, id := range r.registry.Conflicts() {
+		primary := r.registry.Get(id)
+		for _, v := range r.registry.Variants(id) {
+			if v == primary {
+				continue
+			}
+			r.issues = append(r.issues, Issue{
+				Kind:     "duplicate-symbol",
+				SymbolID: id,
+				File:     v.Location.File,
+				Line:     v.Location.StartLine,
+				Message:  fmt.Sprintf("shadowed by the declaration in %s:%d", primary.Location.File, primary.Location.StartLine),
+			})
+		}
+	}
 }
 
 // resolveInheritance connects extends/implements to actual symbol IDs.
 func (r *Resolver) resolveInheritance() {
-	for _, sym := range r.registry.All() {
-		if sym.Kind != model.KindClass && sym.Kind != model.KindInterface {
+	for _, sym := range r.registry.Declarations() {
+		if sym.Kind != model.KindClass && sym.Kind != model.KindInterface && sym.Kind != model.KindEnum && sym.Kind != model.KindTrait {
 			continue
 		}
 
 		for i, ext := range sym.Extends {
-			if resolved := r.findSymbol(ext); resolved != nil {
+			if resolved := r.findClassRef(sym, ext); resolved != nil {
 				sym.Extends[i] = resolved.ID
 				r.stats.Inheritance++
 				r.stats.Resolved++
 			}
 		}
 		for i, impl := range sym.Implements {
-			if resolved := r.findSymbol(impl); resolved != nil {
+			if resolved := r.findClassRef(sym, impl); resolved != nil {
 				sym.Implements[i] = resolved.ID
 				r.stats.Inheritance++
 				r.stats.Resolved++
 			}
 		}
+		for i, trait := range sym.Traits {
+			if resolved := r.findClassRef(sym, trait); resolved != nil {
+				sym.Traits[i] = resolved.ID
+				r.stats.Inheritance++
+				r.stats.Resolved++
+			}
+		}
+		for i := range sym.TraitRules {
+			rule := &sym.TraitRules[i]
+			if resolved := r.findClassRef(sym, rule.Trait); rule.Trait != "" && resolved != nil {
+				rule.Trait = resolved.ID
+			}
+			for j, other := range rule.InsteadOf {
+				if resolved := r.findClassRef(sym, other); resolved != nil {
+					rule.InsteadOf[j] = resolved.ID
+				}
+			}
+		}
+	}
+
+	done := make(map[*model.Symbol]bool)
+	for _, sym := range r.registry.Declarations() {
+		r.resolveTraitMembers(sym, done)
 	}
 }
 
-// resolveHookBindings links add_action/add_filter calls to hook definitions.
-func (r *Resolver) resolveHookBindings() {
-	hooks := r.registry.ByKind(model.KindHook)
-	hooksByTag := make(map[string]*model.Symbol)
-	for _, h := range hooks {
-		hooksByTag[h.HookTag] = h
+// findClassRef resolves a class name used in sym's Extends, Implements or
+// Traits. PHP names follow PHP's rules; other languages use findSymbol.
+func (r *Resolver) findClassRef(sym *model.Symbol, name string) *model.Symbol {
+	if sym.Language == "php" {
+		return r.findPHPClass(sym, name)
 	}
+	return r.findJSSymbol(sym, name)
+}
 
-	for _, sym := range r.registry.All() {
+// resolveTraitMembers computes the methods a class, trait or enum gains from
+// the traits it uses, applying insteadof exclusions and as aliases. Traits
+// using other traits are resolved first so their members carry through.
+func (r *Resolver) resolveTraitMembers(sym *model.Symbol, done map[*model.Symbol]bool) {
+	if done[sym] || len(sym.Traits) == 0 {
+		return
+	}
+	done[sym] = true
+
+	declared := make(map[string]bool)
+	for _, id := range sym.Members {
+		if m := r.registry.Get(id); m != nil && m.Kind == model.KindMethod {
+			declared[strings.ToLower(m.Name)] = true
+		}
+	}
+
+	for _, traitID := range sym.Traits {
+		trait := r.registry.Get(traitID)
+		if trait == nil || trait.Kind != model.KindTrait {
+			continue
+		}
+		r.resolveTraitMembers(trait, done)
+
+		for _, tm := range traitMethods(r.registry, trait) {
+			excluded, visibility := false, ""
+			for _, rule := range sym.TraitRules {
+				if !strings.EqualFold(rule.Method, tm.Name) {
+					continue
+				}
+				// `A::hello insteadof B` excludes B::hello
+				for _, other := range rule.InsteadOf {
+					if other == traitID {
+						excluded = true
+					}
+				}
+				if rule.Trait != "" && rule.Trait != traitID {
+					continue
+				}
+				if rule.Alias != "" {
+					// The alias is added even when the original name is excluded.
+					alias := tm
+					alias.Name = rule.Alias
+					alias.Visibility = rule.Visibility
+					sym.TraitMembers = append(sym.TraitMembers, alias)
+				} else if rule.Visibility != "" && len(rule.InsteadOf) == 0 {
+					visibility = rule.Visibility
+				}
+			}
+			// Methods declared on the using class take precedence over trait methods.
+			if excluded || declared[strings.ToLower(tm.Name)] {
+				continue
+			}
+			if visibility != "" {
+				tm.Visibility = visibility
+			}
+			sym.TraitMembers = append(sym.TraitMembers, tm)
+		}
+	}
+}
+
+// resolveMemberSets computes the complete member set of every class-like
+// symbol: its own methods, properties and constants, followed by members
+// inherited from traits, the Extends chain and interfaces.
+func (r *Resolver) resolveMemberSets() {
+	memo := make(map[*model.Symbol][]model.MemberRef)
+	for _, sym := range r.registry.Declarations() {
+		switch sym.Kind {
+		case model.KindClass, model.KindInterface, model.KindTrait, model.KindEnum:
+			sym.AllMembers = r.memberSet(sym, memo, make(map[*model.Symbol]bool))
+		}
+	}
+}
+
+// memberSet returns the complete member set of sym, memoized per declaration.
+// visiting guards against inheritance cycles in broken code.
+func (r *Resolver) memberSet(sym *model.Symbol, memo map[*model.Symbol][]model.MemberRef, visiting map[*model.Symbol]bool) []model.MemberRef {
+	if set, ok := memo[sym]; ok {
+		return set
+	}
+	if visiting[sym] {
+		return nil
+	}
+	visiting[sym] = true
+
+	var set []model.MemberRef
+	seen := make(map[string]int) // memberKey -> index in set
+	add := func(ref model.MemberRef) {
+		key := memberKey(ref.Kind, ref.Name)
+		if i, ok := seen[key]; ok {
+			// Already declared here; an inherited member of the same name is overridden.
+			if set[i].Status == model.MemberDeclared && ref.Status == model.MemberInherited {
+				set[i].Status = model.MemberOverridden
+			}
+			return
+		}
+		seen[key] = len(set)
+		set = append(set, ref)
+	}
+
+	for _, id := range sym.Members {
+		if m := r.registry.Get(id); m != nil {
+			add(model.MemberRef{ID: m.ID, Name: m.Name, Kind: m.Kind, Origin: sym.ID, Status: model.MemberDeclared})
+		}
+	}
+	for _, tm := range sym.TraitMembers {
+		add(model.MemberRef{ID: tm.MethodID, Name: tm.Name, Kind: model.KindMethod, Origin: tm.Trait, Status: model.MemberInherited})
+	}
+	// Traits also bring their properties and constants, which insteadof
+	// and as rules don't apply to.
+	for _, traitID := range sym.Traits {
+		trait := r.registry.Get(traitID)
+		if trait == nil || trait.Kind != model.KindTrait {
+			continue
+		}
+		for _, ref := range r.memberSet(trait, memo, visiting) {
+			if ref.Kind == model.KindMethod {
+				continue
+			}
+			ref.Status = model.MemberInherited
+			add(ref)
+		}
+	}
+
+	parents := append(append([]string{}, sym.Extends...), sym.Implements...)
+	for _, parentID := range parents {
+		parent := r.registry.Get(parentID)
+		if parent == nil {
+			continue
+		}
+		for _, ref := range r.memberSet(parent, memo, visiting) {
+			if r.isPrivateMember(ref.ID) {
+				continue
+			}
+			ref.Status = model.MemberInherited
+			add(ref)
+		}
+	}
+
+	visiting[sym] = false
+	memo[sym] = set
+	return set
+}
+
+// memberKey identifies a member by kind and name. Method names are
+// case-insensitive in PHP; property and constant names are not.
+func memberKey(kind model.SymbolKind, name string) string {
+	if kind == model.KindMethod {
+		name = strings.ToLower(name)
+	}
+	return string(kind) + ":" + name
+}
+
+// isPrivateMember reports whether a member is private, and so not inherited.
+func (r *Resolver) isPrivateMember(id string) bool {
+	m := r.registry.Get(id)
+	return m != nil && m.Visibility() == "private"
+}
+
+// traitMethods lists the methods a trait provides: its own, followed by those
+// it gets from traits it uses itself (attributed to the trait itself, since
+// that is the trait the using class names in its rules).
+func traitMethods(reg *model.Registry, trait *model.Symbol) []model.TraitMember {
+	var result []model.TraitMember
+	for _, id := range trait.Members {
+		if m := reg.Get(id); m != nil && m.Kind == model.KindMethod {
+			result = append(result, model.TraitMember{Trait: trait.ID, MethodID: m.ID, Name: m.Name})
+		}
+	}
+	for _, tm := range trait.TraitMembers {
+		tm.Trait = trait.ID
+		result = append(result, tm)
+	}
+	return result
+}
+
+// resolveCallGraph turns the raw calls recorded in function and method bodies
+// into Uses/UsedBy links. Calls that don't resolve to a known symbol (PHP
+// built-ins, receivers of unknown type) are counted as unresolved; recursive
+// calls are skipped.
+func (r *Resolver) resolveCallGraph() {
+	// Process callers in ID order so UsedBy lists are deterministic. Every
+	// declaration of a duplicated ID gets its own Uses.
+	callers := r.registry.Declarations()
+	sort.Slice(callers, func(i, j int) bool {
+		a, b := callers[i], callers[j]
+		if a.ID != b.ID {
+			return a.ID < b.ID
+		}
+		if a.Location.File != b.Location.File {
+			return a.Location.File < b.Location.File
+		}
+		return a.Location.StartLine < b.Location.StartLine
+	})
+
+	for _, sym := range callers {
+		for _, call := range sym.Calls {
+			target := r.resolveCall(sym, call)
+			if target == nil {
+				r.stats.Unresolved++
+				continue
+			}
+			if target.ID == sym.ID {
+				continue
+			}
+			sym.Uses = appendUnique(sym.Uses, target.ID)
+			r.addUsedBy(target, sym.ID)
+			r.stats.CallEdges++
+			r.stats.Resolved++
+		}
+	}
+}
+
+// addUsedBy records id as a user of every declaration of target, so each
+// variant's page lists its callers.
+func (r *Resolver) addUsedBy(target *model.Symbol, id string) {
+	variants := r.registry.Variants(target.ID)
+	if len(variants) == 0 {
+		variants = []*model.Symbol{target}
+	}
+	for _, v := range variants {
+		v.UsedBy = appendUnique(v.UsedBy, id)
+	}
+}
+
+// resolveCall finds the symbol a single call expression refers to.
+func (r *Resolver) resolveCall(caller *model.Symbol, call model.CallRef) *model.Symbol {
+	switch call.Kind {
+	case "function":
+		if caller.Language == "php" {
+			return r.findPHPFunction(caller, call.Name)
+		}
+		return r.findFunction(call.Name, caller.Language)
+	case "method":
+		if call.Class == "$this" {
+			return r.findMethod(caller.ParentID, call.Name)
+		}
+		return r.findMethod(r.receiverClass(caller, call.Class), call.Name)
+	case "static":
+		return r.findMethod(r.callClass(caller, call.Class), call.Name)
+	case "new":
+		classID := r.callClass(caller, call.Class)
+		if ctor := r.findMethod(classID, "__construct"); ctor != nil {
+			return ctor
+		}
+		return r.registry.Get(classID)
+	}
+	return nil
+}
+
+// callClass resolves the class part of a static call or `new` expression,
+// handling self, static and parent relative to the calling method.
+func (r *Resolver) callClass(caller *model.Symbol, class string) string {
+	switch strings.ToLower(class) {
+	case "self", "static":
+		return caller.ParentID
+	case "parent":
+		if owner := r.registry.Get(caller.ParentID); owner != nil && len(owner.Extends) > 0 {
+			return owner.Extends[0]
+		}
+		return ""
+	}
+	if sym := r.findPHPClass(caller, class); sym != nil {
+		return sym.ID
+	}
+	return ""
+}
+
+// receiverClass returns the class of a variable a method is called on, when
+// the caller declares it as a parameter of a single class type.
+func (r *Resolver) receiverClass(caller *model.Symbol, variable string) string {
+	name := strings.TrimPrefix(variable, "$")
+	for _, p := range caller.Params {
+		if p.Name != name {
+			continue
+		}
+		if names := typeNames(p.Type); len(names) == 1 {
+			if class := r.findPHPClass(caller, names[0]); class != nil {
+				return class.ID
+			}
+		}
+	}
+	return ""
+}
+
+// findFunction resolves a function name in the given language. Namespaced
+// calls fall back to the global function, as PHP does.
+func (r *Resolver) findFunction(name, language string) *model.Symbol {
+	name = strings.TrimPrefix(name, "\\")
+	for {
+		if sym := r.registry.Get(name); sym != nil && sym.Kind == model.KindFunction && sym.Language == language {
+			return sym
+		}
+		idx := strings.LastIndex(name, "\\")
+		if idx < 0 {
+			return nil
+		}
+		name = name[idx+1:]
+	}
+}
+
+// findMethod looks up a method on a class: its own methods, then those it
+// gets from traits, then up the Extends chain. Method names are
+// case-insensitive.
+func (r *Resolver) findMethod(classID, name string) *model.Symbol {
+	seen := make(map[string]bool)
+	for classID != "" && !seen[classID] {
+		seen[classID] = true
+		class := r.lookupPHP(classID)
+		if class == nil {
+			return nil
+		}
+		for _, id := range class.Members {
+			if m := r.registry.Get(id); m != nil && m.Kind == model.KindMethod && strings.EqualFold(m.Name, name) {
+				return m
+			}
+		}
+		for _, tm := range class.TraitMembers {
+			if strings.EqualFold(tm.Name, name) {
+				return r.registry.Get(tm.MethodID)
+			}
+		}
+		if len(class.Extends) == 0 {
+			return nil
+		}
+		classID = class.Extends[0]
+	}
+	return nil
+}
+
+// resolveHookCallSites puts each hook's call sites in source order (parser
+// workers append them in whatever order files happen to be processed) and
+// links "This filter is documented in <file>" call sites to the documented
+// call site in that file, reporting references that don't hold up.
+func (r *Resolver) resolveHookCallSites() {
+	for _, hook := range r.registry.ByKind(model.KindHook) {
+		sort.SliceStable(hook.CallSites, func(i, j int) bool {
+			a, b := hook.CallSites[i], hook.CallSites[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			return a.Line < b.Line
+		})
+
+		for i := range hook.CallSites {
+			site := &hook.CallSites[i]
+			if site.DocumentedIn == "" {
+				continue
+			}
+			if line := documentedSiteLine(hook, site.DocumentedIn); line > 0 {
+				site.DocumentedLine = line
+				r.stats.Resolved++
+				continue
+			}
+			r.stats.Unresolved++
+			r.issues = append(r.issues, Issue{
+				Kind:     "hook-doc-missing",
+				SymbolID: hook.ID,
+				File:     site.File,
+				Line:     site.Line,
+				Message:  fmt.Sprintf("says it is documented in %s, but no documented call site was found there", site.DocumentedIn),
+			})
+		}
+	}
+}
+
+// documentedSiteLine returns the line of the first call site of hook in file
+// that carries a real docblock (not another duplicate-hook pointer).
+func documentedSiteLine(hook *model.Symbol, file string) int {
+	for _, site := range hook.CallSites {
+		if site.File == file && site.DocumentedIn == "" && site.Doc.Summary != "" {
+			return site.Line
+		}
+	}
+	return 0
+}
+
+// resolveGlobals links functions and methods to the global variables they
+// declare with @global tags. A global assigned without a docblock takes its
+// type and description from the first @global tag that gives them.
+func (r *Resolver) resolveGlobals() {
+	for _, sym := range r.registry.Declarations() {
+		if sym.Kind != model.KindFunction && sym.Kind != model.KindMethod {
+			continue
+		}
+		for _, id := range sym.Uses {
+			if global := r.registry.Get(id); global != nil && global.Kind == model.KindGlobal {
+				global.UsedBy = appendUnique(global.UsedBy, sym.ID)
+				r.stats.Resolved++
+			}
+		}
+	}
+	for _, global := range r.registry.ByKind(model.KindGlobal) {
+		sort.Strings(global.UsedBy)
+		for _, id := range global.UsedBy {
+			if global.Type != "" && global.Doc.Summary != "" {
+				break
+			}
+			user := r.registry.Get(id)
+			if user == nil {
+				continue
+			}
+			typ, summary := globalTag(user, global.Name)
+			if global.Type == "" {
+				global.Type = typ
+			}
+			if global.Doc.Summary == "" {
+				global.Doc.Summary = summary
+			}
+		}
+	}
+}
+
+// globalTag returns the type and description sym's @global tag gives the
+// named global ("$wpdb").
+func globalTag(sym *model.Symbol, name string) (typ, summary string) {
+	for _, tag := range sym.Doc.Globals {
+		if "$"+tag.Name == name {
+			return tag.Type, tag.Description
+		}
+	}
+	return "", ""
+}
+
+// resolveHookBindings links add_action/add_filter calls to hook definitions.
+func (r *Resolver) resolveHookBindings() {
+	for _, sym := range r.registry.Declarations() {
 		if sym.Kind != model.KindFunction && sym.Kind != model.KindMethod {
 			continue
 		}
 		for _, hookID := range sym.Uses {
-			if hook, ok := hooksByTag[hookID]; ok {
+			if hook := r.registry.Get(hookID); hook != nil && hook.Kind == model.KindHook {
 				hook.UsedBy = appendUnique(hook.UsedBy, sym.ID)
 				r.stats.HookBindings++
 				r.stats.Resolved++
 			}
 		}
 	}
+
+	// Attach each registration to its hook in source order so output is
+	// stable regardless of which worker parsed which file first.
+	callbacks := r.registry.HookCallbacks()
+	sort.SliceStable(callbacks, func(i, j int) bool {
+		if callbacks[i].File != callbacks[j].File {
+			return callbacks[i].File < callbacks[j].File
+		}
+		return callbacks[i].Line < callbacks[j].Line
+	})
+	dynamic := r.dynamicHooks()
+	for _, cb := range callbacks {
+		hookID, findCallback := "hook:"+cb.Tag, r.findCallback
+		if cb.Language == "js" {
+			hookID = "jshook:" + cb.Tag
+			findCallback = func(name string) *model.Symbol { return r.findJSCallback(cb.File, name) }
+		}
+		hook := r.registry.Get(hookID)
+		if hook == nil {
+			// add_action( 'save_post_page', ... ) binds to save_post_{$post->post_type}
+			if hook = matchDynamicHook(dynamic, cb.Tag, cb.Language); hook != nil {
+				hook.Expansions = appendUnique(hook.Expansions, cb.Tag)
+			}
+		}
+		if hook == nil {
+			r.stats.Unresolved++
+			continue
+		}
+		if target := findCallback(cb.Callback); target != nil {
+			cb.CallbackID = target.ID
+		}
+		hook.Callbacks = append(hook.Callbacks, cb)
+		r.stats.HookBindings++
+		r.stats.Resolved++
+	}
+}
+
+// maxHookExpansions caps how many concrete tags are inferred for one dynamic
+// hook, so hooks with several placeholders don't explode combinatorially.
+const maxHookExpansions = 100
+
+// placeholderRegex matches a {$expression} placeholder in a dynamic hook tag.
+var placeholderRegex = regexp.MustCompile(`\{[^{}]*\}`)
+
+// Characters a placeholder can stand for in a concrete tag. PHP values are
+// slugs and names; JS hooks also take block names (core/paragraph) and
+// dotted namespaces.
+const (
+	phpPlaceholderChars = `[A-Za-z0-9_\-]+`
+	jsPlaceholderChars  = `[A-Za-z0-9_\-/.]+`
+)
+
+// dynamicHook pairs a dynamic hook with a pattern matching its concrete tags.
+type dynamicHook struct {
+	hook    *model.Symbol
+	pattern *regexp.Regexp
+	literal int // Number of literal (non-placeholder) characters in the tag
+}
+
+// dynamicHooks compiles a pattern for every dynamic hook with some literal text.
+func (r *Resolver) dynamicHooks() []dynamicHook {
+	var result []dynamicHook
+	for _, hook := range r.registry.ByKind(model.KindHook) {
+		if !hook.IsDynamic {
+			continue
+		}
+		chars := phpPlaceholderChars
+		if hook.Language == "js" {
+			chars = jsPlaceholderChars
+		}
+		var b strings.Builder
+		b.WriteString("^")
+		last, literal := 0, 0
+		for _, loc := range placeholderRegex.FindAllStringIndex(hook.HookTag, -1) {
+			b.WriteString(regexp.QuoteMeta(hook.HookTag[last:loc[0]]))
+			b.WriteString(chars)
+			literal += loc[0] - last
+			last = loc[1]
+		}
+		b.WriteString(regexp.QuoteMeta(hook.HookTag[last:]))
+		b.WriteString("$")
+		literal += len(hook.HookTag) - last
+		if literal == 0 {
+			continue // e.g. do_action( "{$hook}" ) matches everything
+		}
+		result = append(result, dynamicHook{hook: hook, pattern: regexp.MustCompile(b.String()), literal: literal})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].hook.ID < result[j].hook.ID })
+	return result
+}
+
+// matchDynamicHook returns the most specific dynamic hook of the given
+// language ("php" or "js") matching a concrete tag.
+func matchDynamicHook(dynamic []dynamicHook, tag, language string) *model.Symbol {
+	var best *dynamicHook
+	for i := range dynamic {
+		d := &dynamic[i]
+		if d.hook.Language != language {
+			continue
+		}
+		if d.pattern.MatchString(tag) && (best == nil || d.literal > best.literal) {
+			best = d
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return best.hook
+}
+
+// resolveDynamicHooks lists concrete expansions of dynamic hooks whose
+// placeholders range over registered post types, post statuses or taxonomies,
+// e.g. save_post_{$post->post_type} -> save_post_page.
+func (r *Resolver) resolveDynamicHooks() {
+	for _, hook := range r.registry.ByKind(model.KindHook) {
+		if !hook.IsDynamic {
+			continue
+		}
+		placeholders := placeholderRegex.FindAllString(hook.HookTag, -1)
+		var values [][]string
+		total := 1
+		for _, ph := range placeholders {
+			names := r.registry.RegisteredNames(placeholderCategory(ph, hook))
+			if len(names) == 0 {
+				values = nil
+				break
+			}
+			values = append(values, names)
+			total *= len(names)
+		}
+		if len(values) > 0 && total <= maxHookExpansions {
+			for _, tag := range expandPlaceholders(hook.HookTag, placeholders, values) {
+				hook.Expansions = appendUnique(hook.Expansions, tag)
+			}
+		}
+		sort.Strings(hook.Expansions)
+	}
+}
+
+// placeholderCategory guesses which registered-name category a placeholder
+// ranges over from its expression. Returns "" when it can't tell.
+func placeholderCategory(placeholder string, hook *model.Symbol) string {
+	expr := strings.Trim(placeholder, "{}")
+	switch {
+	case strings.Contains(expr, "post_type"):
+		return "post_type"
+	case strings.Contains(expr, "taxonomy"):
+		return "taxonomy"
+	case strings.Contains(expr, "post_status"):
+		return "post_status"
+	case expr == "$old_status" || expr == "$new_status":
+		// Shared by post and comment transitions; only trust it for hooks
+		// fired from a post status function.
+		for _, site := range hook.CallSites {
+			if strings.Contains(site.CallerID, "post_status") {
+				return "post_status"
+			}
+		}
+	}
+	return ""
+}
+
+// expandPlaceholders substitutes every combination of values into tag.
+func expandPlaceholders(tag string, placeholders []string, values [][]string) []string {
+	results := []string{tag}
+	for i, ph := range placeholders {
+		var next []string
+		for _, partial := range results {
+			for _, v := range values[i] {
+				next = append(next, strings.Replace(partial, ph, v, 1))
+			}
+		}
+		results = next
+	}
+	return results
+}
+
+// findCallback resolves a hook callback name ("my_func" or "Class::method")
+// to a function or method symbol. Callables are fully qualified names and,
+// like all PHP function and class names, case-insensitive. Methods may be
+// inherited. Closures and variable callables never resolve.
+func (r *Resolver) findCallback(name string) *model.Symbol {
+	if name == "" || strings.HasPrefix(name, "{") || strings.HasPrefix(name, "$") {
+		return nil
+	}
+	if class, method, ok := strings.Cut(name, "::"); ok {
+		return r.findMethod(class, method)
+	}
+	if sym := r.lookupPHP(name); sym != nil && sym.Kind == model.KindFunction {
+		return sym
+	}
+	return nil
+}
+
+// findJSCallback resolves a JS hook callback: a function declared in the
+// file that registers it, or any JS function of that name.
+func (r *Resolver) findJSCallback(file, name string) *model.Symbol {
+	if name == "" || strings.HasPrefix(name, "{") {
+		return nil
+	}
+	for _, sym := range r.registry.ByFile(file) {
+		if sym.Language == "js" && sym.Name == name && (sym.Kind == model.KindFunction || sym.Kind == model.KindMethod) {
+			return sym
+		}
+	}
+	if sym := r.findSymbol(name, "js"); sym != nil && sym.Kind == model.KindFunction {
+		return sym
+	}
+	return nil
+}
+
+// resolveDeprecations attaches _deprecated_*() notices to the symbols (or
+// every symbol in the file) they deprecate, links the replacement, and fills
+// in DocBlock.Deprecated where the docblock has no @deprecated tag.
+func (r *Resolver) resolveDeprecations() {
+	deprecations := r.registry.Deprecations()
+	sort.Slice(deprecations, func(i, j int) bool {
+		if deprecations[i].File != deprecations[j].File {
+			return deprecations[i].File < deprecations[j].File
+		}
+		return deprecations[i].Line < deprecations[j].Line
+	})
+
+	for _, d := range deprecations {
+		var targets []*model.Symbol
+		if d.TargetFile != "" {
+			targets = r.fileSymbols(d.TargetFile)
+		} else if sym := r.registry.Get(d.TargetID); sym != nil {
+			targets = []*model.Symbol{sym}
+		}
+		if len(targets) == 0 {
+			r.stats.Unresolved++
+			continue
+		}
+
+		if replacement := r.findReplacement(d); replacement != nil {
+			d.ReplacementID = replacement.ID
+			r.stats.Resolved++
+		}
+		for _, sym := range targets {
+			sym.Deprecations = append(sym.Deprecations, d)
+			// A deprecated argument doesn't deprecate the function itself.
+			if d.Function != "_deprecated_argument" && sym.Doc.Deprecated == "" {
+				sym.Doc.Deprecated = deprecationText(d)
+			}
+		}
+	}
+}
+
+// fileSymbols returns the top-level symbols declared in a deprecated file.
+// Paths that aren't known as-is are matched by suffix, since core sometimes
+// names the file relative to wp-includes.
+func (r *Resolver) fileSymbols(file string) []*model.Symbol {
+	symbols := r.registry.ByFile(file)
+	if len(symbols) == 0 {
+		for _, path := range r.registry.Files() {
+			if strings.HasSuffix(path, "/"+file) {
+				symbols = r.registry.ByFile(path)
+				break
+			}
+		}
+	}
+	// Hooks and globals are shared across files; a deprecated file that
+	// fires a hook or uses $wpdb doesn't deprecate them.
+	var result []*model.Symbol
+	for _, sym := range symbols {
+		if sym.ParentID == "" && sym.Kind != model.KindHook && sym.Kind != model.KindGlobal {
+			result = append(result, sym)
+		}
+	}
+	return result
+}
+
+// replacementRegex matches replacements that name a single symbol, such as
+// "wp_get_current_user()", "WP_Query::get_posts()" or "pre_get_avatar_data".
+var replacementRegex = regexp.MustCompile(`^\\?[A-Za-z_][\w\\]*(?:::\w+)?(?:\(\))?$`)
+
+// findReplacement resolves the replacement named by a deprecation notice.
+func (r *Resolver) findReplacement(d model.Deprecation) *model.Symbol {
+	name := strings.TrimSpace(d.Replacement)
+	if !replacementRegex.MatchString(name) {
+		return nil
+	}
+	name = strings.TrimPrefix(strings.TrimSuffix(name, "()"), "\\")
+	if strings.HasPrefix(d.TargetID, "hook:") {
+		if hook := r.registry.Get("hook:" + name); hook != nil {
+			return hook
+		}
+	}
+	return r.findSymbol(name, "php")
+}
+
+// deprecationText renders a notice in the style of an @deprecated tag,
+// e.g. "3.0.0 Use wp_get_current_user() instead."
+func deprecationText(d model.Deprecation) string {
+	text := d.Version
+	switch {
+	case d.Replacement != "":
+		text += " Use " + d.Replacement + " instead."
+	case d.Message != "":
+		text += " " + d.Message
+	}
+	return strings.TrimSpace(text)
 }
 
-// resolveSeeReferences resolves @see tags to symbol IDs.
+// resolveSeeReferences resolves @see tags and inline {@see} and {@link}
+// tags to symbol IDs.
 func (r *Resolver) resolveSeeReferences() {
-	for _, sym := range r.registry.All() {
+	for _, sym := range r.registry.Declarations() {
 		for i, ref := range sym.Doc.SeeAlso {
-			// Try to resolve the reference to an actual symbol
-			ref = strings.TrimSpace(ref)
-			if ref == "" {
+			if resolved := r.resolveDocReference(sym, ref); resolved != nil {
+				sym.Doc.SeeAlso[i] = resolved.ID
+			}
+		}
+		for i, tag := range sym.Doc.Inline {
+			if tag.IsURL() {
 				continue
 			}
-			// Strip trailing () for function references
-			cleanRef := strings.TrimSuffix(ref, "()")
-			if resolved := r.findSymbol(cleanRef); resolved != nil {
-				sym.Doc.SeeAlso[i] = resolved.ID
-				r.stats.Resolved++
-			} else {
-				r.stats.Unresolved++
+			if resolved := r.resolveDocReference(sym, tag.Target); resolved != nil {
+				sym.Doc.Inline[i].TargetID = resolved.ID
 			}
 		}
 	}
 }
 
-// resolveMethodOverrides finds parent methods that child methods override.
+// resolveDocReference resolves a reference such as "WP_Query::query()" or
+// "wp_insert_post()" written in sym's documentation, updating the stats.
+func (r *Resolver) resolveDocReference(sym *model.Symbol, ref string) *model.Symbol {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil
+	}
+	// Strip trailing () for function references
+	cleanRef := strings.TrimSuffix(ref, "()")
+	var resolved *model.Symbol
+	switch {
+	case isQuoted(cleanRef):
+		// Hooks are referenced by their quoted tag: {@see 'init'}
+		prefix := "hook:"
+		if sym.Language == "js" {
+			prefix = "jshook:"
+		}
+		resolved = r.registry.Get(prefix + cleanRef[1:len(cleanRef)-1])
+	case sym.Language == "php":
+		resolved = r.findPHPReference(sym, cleanRef)
+	default:
+		resolved = r.findJSSymbol(sym, cleanRef)
+	}
+	if resolved != nil {
+		r.stats.Resolved++
+	} else {
+		r.stats.Unresolved++
+	}
+	return resolved
+}
+
+// isQuoted reports whether s is wrapped in single or double quotes.
+func isQuoted(s string) bool {
+	return len(s) >= 2 && (s[0] == '\'' || s[0] == '"') && s[len(s)-1] == s[0]
+}
+
+// resolveMethodOverrides links each method to the nearest ancestor method it
+// overrides (own traits first, then the Extends chain) and to every interface
+// method it implements, recording the reverse links in OverriddenBy.
 func (r *Resolver) resolveMethodOverrides() {
-	for _, sym := range r.registry.All() {
-		if sym.Kind != model.KindMethod || sym.ParentID == "" {
+	for _, sym := range r.registry.ByKind(model.KindMethod) {
+		if sym.ParentID == "" || r.registry.Get(sym.ID) != sym {
 			continue
 		}
-
-		parent := r.registry.Get(sym.ParentID)
-		if parent == nil {
+		class := r.registry.Get(sym.ParentID)
+		if class == nil {
 			continue
 		}
 
-		// Walk up the inheritance chain
-		for _, extID := range parent.Extends {
-			extSym := r.registry.Get(extID)
-			if extSym == nil {
-				continue
+		if overridden := r.overriddenMethod(class, sym.Name); overridden != nil {
+			sym.Overrides = overridden.ID
+			overridden.OverriddenBy = appendUnique(overridden.OverriddenBy, sym.ID)
+			r.stats.Resolved++
+		}
+		if class.Kind == model.KindInterface {
+			continue
+		}
+		for _, ifaceID := range r.allInterfaces(class, make(map[string]bool)) {
+			iface := r.registry.Get(ifaceID)
+			for _, id := range iface.Members {
+				m := r.registry.Get(id)
+				if m != nil && m.Kind == model.KindMethod && strings.EqualFold(m.Name, sym.Name) {
+					sym.ImplementsMethods = appendUnique(sym.ImplementsMethods, m.ID)
+					m.OverriddenBy = appendUnique(m.OverriddenBy, sym.ID)
+					r.stats.Resolved++
+				}
 			}
-			// Look for a method with the same name in the parent class
-			parentMethodID := extID + "::" + sym.Name
-			if parentMethod := r.registry.Get(parentMethodID); parentMethod != nil {
-				sym.Overrides = parentMethod.ID
-				parentMethod.UsedBy = appendUnique(parentMethod.UsedBy, sym.ID)
-				r.stats.Resolved++
-				break
+		}
+	}
+
+	for _, sym := range r.registry.ByKind(model.KindMethod) {
+		sort.Strings(sym.OverriddenBy)
+	}
+}
+
+// overriddenMethod finds the nearest non-interface method named name that
+// class inherits: from its own traits, then up the Extends chain. For
+// interfaces, the parent interfaces' methods count.
+func (r *Resolver) overriddenMethod(class *model.Symbol, name string) *model.Symbol {
+	key := memberKey(model.KindMethod, name)
+	for _, traitID := range class.Traits {
+		if trait := r.registry.Get(traitID); trait != nil {
+			if m := r.findMemberRef(trait, key, false); m != nil {
+				return m
 			}
 		}
 	}
+	for _, parentID := range class.Extends {
+		parent := r.registry.Get(parentID)
+		if parent == nil {
+			continue
+		}
+		m := r.findMemberRef(parent, key, true)
+		if m == nil {
+			continue
+		}
+		origin := r.registry.Get(m.ParentID)
+		if class.Kind == model.KindInterface || origin == nil || origin.Kind != model.KindInterface {
+			return m
+		}
+	}
+	return nil
+}
+
+// findMemberRef looks a member up in a class's complete member set by key.
+// Private members are skipped when they wouldn't be inherited; a trait's
+// private methods are still copied into the using class.
+func (r *Resolver) findMemberRef(class *model.Symbol, key string, skipPrivate bool) *model.Symbol {
+	for _, ref := range class.AllMembers {
+		if memberKey(ref.Kind, ref.Name) == key && !(skipPrivate && r.isPrivateMember(ref.ID)) {
+			return r.registry.Get(ref.ID)
+		}
+	}
+	return nil
+}
+
+// allInterfaces returns every interface class implements, directly, through
+// parent interfaces, or through its ancestors, in a stable order.
+func (r *Resolver) allInterfaces(class *model.Symbol, seen map[string]bool) []string {
+	var result []string
+	for _, id := range append(append([]string{}, class.Implements...), class.Extends...) {
+		sym := r.registry.Get(id)
+		if sym == nil || seen[id] {
+			continue
+		}
+		seen[id] = true
+		if sym.Kind == model.KindInterface {
+			result = append(result, id)
+		}
+		result = append(result, r.allInterfaces(sym, seen)...)
+	}
+	return result
 }
 
-// findSymbol attempts to locate a symbol by name, trying various qualification strategies.
-func (r *Resolver) findSymbol(name string) *model.Symbol {
+// findSymbol attempts to locate a symbol of the given language by name,
+// trying various qualification strategies.
+func (r *Resolver) findSymbol(name, language string) *model.Symbol {
 	// Direct lookup
-	if s := r.registry.Get(name); s != nil {
+	if s := r.registry.Get(name); s != nil && s.Language == language {
 		return s
 	}
 
 	// Try with backslash-separated namespace
-	if s := r.registry.Get(strings.ReplaceAll(name, "/", "\\")); s != nil {
+	if s := r.registry.Get(strings.ReplaceAll(name, "/", "\\")); s != nil && s.Language == language {
 		return s
 	}
 
@@ -152,7 +1068,7 @@ func (r *Resolver) findSymbol(name string) *model.Symbol {
 	// Search all symbols for a match by short name
 	var candidates []*model.Symbol
 	for _, sym := range r.registry.All() {
-		if sym.Name == shortName {
+		if sym.Name == shortName && sym.Language == language {
 			candidates = append(candidates, sym)
 		}
 	}
@@ -160,13 +1076,21 @@ func (r *Resolver) findSymbol(name string) *model.Symbol {
 	if len(candidates) == 1 {
 		return candidates[0]
 	}
-
-	// If multiple candidates, prefer same language
-	// (this is a heuristic; could be improved with namespace context)
-
 	return nil
 }
 
+// findJSSymbol resolves a name used in a JS/TS symbol. JS IDs are qualified
+// with their module (sym.Namespace), so the caller's own module is tried
+// first, then a JS symbol whose short name is unique.
+func (r *Resolver) findJSSymbol(sym *model.Symbol, name string) *model.Symbol {
+	if sym.Namespace != "" {
+		if target := r.registry.Get(sym.Namespace + "." + name); target != nil {
+			return target
+		}
+	}
+	return r.findSymbol(name, "js")
+}
+
 func appendUnique(slice []string, val string) []string {
 	for _, s := range slice {
 		if s == val {
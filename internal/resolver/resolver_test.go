@@ -0,0 +1,231 @@
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+func TestFindCallback(t *testing.T) {
+	reg := model.NewRegistry()
+	for _, sym := range []*model.Symbol{
+		{ID: `My\Plugin\on_init`, Name: "on_init", Kind: model.KindFunction, Language: "php"},
+		{ID: `My\Plugin\Base`, Name: "Base", Kind: model.KindClass, Language: "php", Members: []string{`My\Plugin\Base::Boot`}},
+		{ID: `My\Plugin\Base::Boot`, Name: "Boot", Kind: model.KindMethod, Language: "php", ParentID: `My\Plugin\Base`},
+		{ID: `My\Plugin\Loader`, Name: "Loader", Kind: model.KindClass, Language: "php", Extends: []string{`My\Plugin\Base`}},
+		{ID: "onInit", Name: "onInit", Kind: model.KindFunction, Language: "js"},
+		{ID: "WP_CONST", Name: "WP_CONST", Kind: model.KindConstant, Language: "php"},
+	} {
+		reg.Add(sym)
+	}
+	r := New(reg)
+
+	tests := []struct {
+		callback string
+		want     string
+	}{
+		{`My\Plugin\on_init`, `My\Plugin\on_init`},
+		{`my\plugin\ON_INIT`, `My\Plugin\on_init`},
+		{`My\Plugin\Loader::boot`, `My\Plugin\Base::Boot`},
+		{"on_init", ""},   // String callables are fully qualified
+		{"onInit", ""},    // JS functions never back PHP callbacks
+		{"WP_CONST", ""},  // Only functions and methods are callable
+		{"{closure}", ""}, // Closures never resolve
+		{"$callback", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if sym := r.findCallback(tt.callback); sym != nil {
+			got = sym.ID
+		}
+		if got != tt.want {
+			t.Errorf("findCallback(%q) = %q, want %q", tt.callback, got, tt.want)
+		}
+	}
+}
+
+func TestMatchDynamicHook(t *testing.T) {
+	reg := model.NewRegistry()
+	for _, tag := range []string{
+		"save_post_{$post->post_type}",
+		"{$new_status}_{$post->post_type}",
+		"pre_option_{$option}",
+		"{$hook}",
+	} {
+		reg.Add(&model.Symbol{ID: "hook:" + tag, Kind: model.KindHook, Language: "php", HookTag: tag, IsDynamic: true})
+	}
+	for _, tag := range []string{
+		"blocks.{name}.edit",
+		"editor.{hookName}",
+	} {
+		reg.Add(&model.Symbol{ID: "jshook:" + tag, Kind: model.KindHook, Language: "js", HookTag: tag, IsDynamic: true})
+	}
+	r := New(reg)
+	dynamic := r.dynamicHooks()
+
+	tests := []struct {
+		tag, language, want string
+	}{
+		{"save_post_page", "php", "hook:save_post_{$post->post_type}"},
+		{"save_post_my-type", "php", "hook:save_post_{$post->post_type}"},
+		{"publish_page", "php", "hook:{$new_status}_{$post->post_type}"},
+		{"pre_option_blogname", "php", "hook:pre_option_{$option}"},
+		{"init", "php", ""},
+		{"save_post_a/b", "php", ""},
+		{"blocks.core/paragraph.edit", "js", "jshook:blocks.{name}.edit"},
+		{"editor.BlockEdit.inner", "js", "jshook:editor.{hookName}"},
+		{"blocks.core/paragraph.edit", "php", ""}, // PHP hooks don't match JS tags
+		{"save_post_page", "js", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if hook := matchDynamicHook(dynamic, tt.tag, tt.language); hook != nil {
+			got = hook.ID
+		}
+		if got != tt.want {
+			t.Errorf("matchDynamicHook(%q, %s) = %q, want %q", tt.tag, tt.language, got, tt.want)
+		}
+	}
+}
+
+func TestResolveDocReference(t *testing.T) {
+	reg := model.NewRegistry()
+	for _, sym := range []*model.Symbol{
+		{ID: "hook:init", Name: "init", Kind: model.KindHook, Language: "php", HookTag: "init"},
+		{ID: "jshook:blocks.registerBlockType", Name: "blocks.registerBlockType", Kind: model.KindHook, Language: "js", HookTag: "blocks.registerBlockType"},
+		{ID: "WP_Query", Name: "WP_Query", Kind: model.KindClass, Language: "php", Members: []string{"WP_Query::query"}},
+		{ID: "WP_Query::query", Name: "query", Kind: model.KindMethod, Language: "php", ParentID: "WP_Query"},
+		{ID: "get_post", Name: "get_post", Kind: model.KindFunction, Language: "php"},
+	} {
+		reg.Add(sym)
+	}
+	r := New(reg)
+	php := &model.Symbol{ID: "f", Kind: model.KindFunction, Language: "php"}
+	js := &model.Symbol{ID: "g", Kind: model.KindFunction, Language: "js"}
+
+	tests := []struct {
+		sym  *model.Symbol
+		ref  string
+		want string
+	}{
+		{php, "'init'", "hook:init"},
+		{php, `"init"`, "hook:init"},
+		{js, "'blocks.registerBlockType'", "jshook:blocks.registerBlockType"},
+		{js, "'init'", ""}, // JS symbols only refer to JS hooks
+		{php, "WP_Query::query()", "WP_Query::query"},
+		{php, "wp_query", "WP_Query"},
+		{php, "get_post()", "get_post"},
+		{php, "'unknown_hook'", ""},
+		{php, "missing()", ""},
+		{php, "", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if sym := r.resolveDocReference(tt.sym, tt.ref); sym != nil {
+			got = sym.ID
+		}
+		if got != tt.want {
+			t.Errorf("resolveDocReference(%s, %q) = %q, want %q", tt.sym.Language, tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestFindJSSymbol(t *testing.T) {
+	reg := model.NewRegistry()
+	for _, sym := range []*model.Symbol{
+		{ID: "@wordpress/blocks.normalize", Name: "normalize", Kind: model.KindFunction, Language: "js", Namespace: "@wordpress/blocks"},
+		{ID: "@wordpress/editor.normalize", Name: "normalize", Kind: model.KindFunction, Language: "js", Namespace: "@wordpress/editor"},
+		{ID: "@wordpress/data.select", Name: "select", Kind: model.KindFunction, Language: "js", Namespace: "@wordpress/data"},
+		{ID: "get_post", Name: "get_post", Kind: model.KindFunction, Language: "php"},
+		{ID: "WP_Query", Name: "WP_Query", Kind: model.KindClass, Language: "php"},
+		{ID: `My\WP_Query`, Name: "WP_Query", Kind: model.KindClass, Language: "php", Namespace: "My"},
+	} {
+		reg.Add(sym)
+	}
+	r := New(reg)
+	blocks := &model.Symbol{ID: "@wordpress/blocks.register", Language: "js", Namespace: "@wordpress/blocks"}
+	other := &model.Symbol{ID: "@wordpress/core-data.fetch", Language: "js", Namespace: "@wordpress/core-data"}
+
+	tests := []struct {
+		sym  *model.Symbol
+		name string
+		want string
+	}{
+		{blocks, "normalize", "@wordpress/blocks.normalize"}, // Own module first
+		{other, "normalize", ""},                             // Ambiguous elsewhere
+		{other, "select", "@wordpress/data.select"},          // Unique short name
+		{other, "@wordpress/editor.normalize", "@wordpress/editor.normalize"},
+		{other, "get_post", ""}, // Never a PHP symbol
+		{other, "WP_Query", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if sym := r.findJSSymbol(tt.sym, tt.name); sym != nil {
+			got = sym.ID
+		}
+		if got != tt.want {
+			t.Errorf("findJSSymbol(%s, %q) = %q, want %q", tt.sym.ID, tt.name, got, tt.want)
+		}
+	}
+
+	// Deprecation replacements are PHP names
+	if sym := r.findSymbol("@wordpress/data.select", "php"); sym != nil {
+		t.Errorf(`findSymbol("@wordpress/data.select", "php") = %s, want nil`, sym.ID)
+	}
+	if sym := r.findSymbol("get_post", "php"); sym == nil || sym.ID != "get_post" {
+		t.Errorf(`findSymbol("get_post", "php") = %v, want get_post`, sym)
+	}
+}
+
+func TestResolveCallGraph(t *testing.T) {
+	reg := model.NewRegistry()
+	for _, sym := range []*model.Symbol{
+		{ID: "Logs", Name: "Logs", Kind: model.KindTrait, Language: "php", Members: []string{"Logs::log"}},
+		{ID: "Logs::log", Name: "log", Kind: model.KindMethod, Language: "php", ParentID: "Logs"},
+		{ID: "Base", Name: "Base", Kind: model.KindClass, Language: "php", Members: []string{"Base::save"},
+			TraitMembers: []model.TraitMember{{Trait: "Logs", MethodID: "Logs::log", Name: "log"}}},
+		{ID: "Base::save", Name: "save", Kind: model.KindMethod, Language: "php", ParentID: "Base"},
+		{ID: "Post", Name: "Post", Kind: model.KindClass, Language: "php", Extends: []string{"Base"}, Members: []string{"Post::update"}},
+		{ID: "Post::update", Name: "update", Kind: model.KindMethod, Language: "php", ParentID: "Post", Calls: []model.CallRef{
+			{Kind: "method", Class: "$this", Name: "SAVE"},
+			{Kind: "method", Class: "$this", Name: "Log"},
+			{Kind: "method", Class: "$this", Name: "update"}, // Recursion
+			{Kind: "static", Class: "parent", Name: "save"},
+		}},
+		{ID: "Other", Name: "Other", Kind: model.KindClass, Language: "php", Members: []string{"Other::save"}},
+		{ID: "Other::save", Name: "save", Kind: model.KindMethod, Language: "php", ParentID: "Other"},
+		{ID: "publish", Name: "publish", Kind: model.KindFunction, Language: "php",
+			Params: []model.Param{{Name: "post", Type: "Post"}, {Name: "maybe", Type: "Post|Other"}},
+			Calls: []model.CallRef{
+				{Kind: "method", Class: "$post", Name: "update"},
+				{Kind: "method", Class: "$maybe", Name: "save"},   // Union type, unknown class
+				{Kind: "method", Class: "$unknown", Name: "save"}, // Not a parameter
+				{Kind: "new", Class: "post"},
+				{Kind: "function", Name: "publish"}, // Recursion
+			}},
+	} {
+		reg.Add(sym)
+	}
+	r := New(reg)
+	r.resolveCallGraph()
+
+	tests := []struct {
+		id   string
+		uses []string
+	}{
+		{"Post::update", []string{"Base::save", "Logs::log"}},
+		{"publish", []string{"Post::update", "Post"}},
+	}
+	for _, tt := range tests {
+		if got := reg.Get(tt.id).Uses; !reflect.DeepEqual(got, tt.uses) {
+			t.Errorf("%s Uses = %q, want %q", tt.id, got, tt.uses)
+		}
+	}
+	if got := reg.Get("Other::save").UsedBy; len(got) != 0 {
+		t.Errorf("Other::save UsedBy = %q, want none", got)
+	}
+	if got, want := reg.Get("Base::save").UsedBy, []string{"Post::update"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Base::save UsedBy = %q, want %q", got, want)
+	}
+}
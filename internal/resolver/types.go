@@ -0,0 +1,199 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// docTypeAliases maps docblock spellings to the native type names they mean.
+var docTypeAliases = map[string]string{
+	"integer":  "int",
+	"boolean":  "bool",
+	"double":   "float",
+	"callback": "callable",
+	"$this":    "static",
+}
+
+// checkTypeMismatches reports parameters and return values whose documented
+// type disagrees with the type declared in the signature.
+func (r *Resolver) checkTypeMismatches() {
+	for _, sym := range r.registry.Declarations() {
+		if sym.Language != "php" || (sym.Kind != model.KindFunction && sym.Kind != model.KindMethod) {
+			continue
+		}
+		class := ""
+		if sym.ParentID != "" {
+			class = sym.ParentID[strings.LastIndex(sym.ParentID, "\\")+1:]
+		}
+
+		for _, p := range sym.Params {
+			if p.Native == nil || p.DocType == "" {
+				continue
+			}
+			// `Foo $x = null` is implicitly nullable; the doc may or may not say so.
+			implicitNull := strings.EqualFold(p.Default, "null")
+			if !typesAgree(*p.Native, p.DocType, class, implicitNull) {
+				r.addTypeMismatch(sym, fmt.Sprintf("parameter $%s: documented %q, declared %q", p.Name, p.DocType, p.Native.String()))
+			}
+		}
+		if ret := sym.Returns; ret != nil && ret.Native != nil && ret.Type != ret.Native.String() {
+			if !typesAgree(*ret.Native, ret.Type, class, false) {
+				r.addTypeMismatch(sym, fmt.Sprintf("return: documented %q, declared %q", ret.Type, ret.Native.String()))
+			}
+		}
+	}
+}
+
+func (r *Resolver) addTypeMismatch(sym *model.Symbol, message string) {
+	r.issues = append(r.issues, Issue{
+		Kind:     "type-mismatch",
+		SymbolID: sym.ID,
+		File:     sym.Location.File,
+		Line:     sym.Location.StartLine,
+		Message:  message,
+	})
+}
+
+// typesAgree reports whether every member of the native union is covered by
+// the documented type and vice versa. A mixed on either side agrees with
+// anything. class is the short name of the enclosing class, for self/static.
+func typesAgree(native model.TypeExpr, doc, class string, implicitNull bool) bool {
+	docMembers := splitDocType(doc)
+	if len(docMembers) == 0 {
+		return true
+	}
+	for _, d := range docMembers {
+		if d == "mixed" {
+			return true
+		}
+	}
+	nativeMembers := native.Members()
+	for _, n := range nativeMembers {
+		if n.Kind == model.TypeNamed && strings.EqualFold(n.Name, "mixed") {
+			return true
+		}
+	}
+
+	for _, n := range nativeMembers {
+		covered := false
+		for _, d := range docMembers {
+			if nativeMatchesDoc(n, d, class) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	for _, d := range docMembers {
+		covered := implicitNull && d == "null"
+		for _, n := range nativeMembers {
+			if nativeMatchesDoc(n, d, class) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
+
+// splitDocType splits a documented type on top-level "|" into normalised,
+// lower-cased members. "?T" adds null.
+func splitDocType(doc string) []string {
+	var members []string
+	depth, start := 0, 0
+	add := func(m string) {
+		m = strings.ToLower(strings.TrimSpace(m))
+		if strings.HasPrefix(m, "?") {
+			members = append(members, "null")
+			m = m[1:]
+		}
+		m = strings.TrimPrefix(m, "\\")
+		if alias, ok := docTypeAliases[m]; ok {
+			m = alias
+		}
+		if m != "" {
+			members = append(members, m)
+		}
+	}
+	for i, c := range doc {
+		switch c {
+		case '<', '{', '(':
+			depth++
+		case '>', '}', ')':
+			depth--
+		case '|':
+			if depth == 0 {
+				add(doc[start:i])
+				start = i + 1
+			}
+		}
+	}
+	add(doc[start:])
+	return members
+}
+
+// nativeMatchesDoc reports whether a documented type member d is compatible
+// with a member n of the native type.
+func nativeMatchesDoc(n model.TypeExpr, d, class string) bool {
+	if n.Kind == model.TypeIntersection {
+		// Docblocks rarely spell intersections out; accept any of its parts.
+		if strings.ReplaceAll(strings.ToLower(n.String()), "\\", "") == strings.ReplaceAll(d, "\\", "") {
+			return true
+		}
+		for _, part := range n.Types {
+			if nativeMatchesDoc(part, d, class) {
+				return true
+			}
+		}
+		return false
+	}
+
+	name := strings.ToLower(strings.TrimPrefix(n.Name, "\\"))
+	if name == d || shortTypeName(name) == shortTypeName(d) {
+		return true
+	}
+
+	arrayLike := strings.HasSuffix(d, "[]") || strings.HasPrefix(d, "array<") || strings.HasPrefix(d, "array{") ||
+		strings.HasPrefix(d, "list<") || strings.HasPrefix(d, "non-empty-array") || d == "list"
+	switch name {
+	case "array":
+		return arrayLike
+	case "iterable":
+		return arrayLike || d == "array" || d == "traversable" || strings.HasPrefix(d, "iterable<")
+	case "bool":
+		return d == "true" || d == "false"
+	case "true", "false":
+		return d == "bool"
+	case "self", "static":
+		lc := strings.ToLower(class)
+		return d == "self" || d == "static" || (lc != "" && shortTypeName(d) == lc)
+	case "object":
+		return !isScalarDocType(d)
+	case "callable":
+		return strings.HasPrefix(d, "callable") || d == "closure"
+	case "string":
+		return strings.HasSuffix(d, "-string") || strings.HasPrefix(d, "class-string")
+	case "int":
+		return strings.HasSuffix(d, "-int") || strings.HasPrefix(d, "int<")
+	}
+	return false
+}
+
+func shortTypeName(name string) string {
+	return name[strings.LastIndex(name, "\\")+1:]
+}
+
+func isScalarDocType(d string) bool {
+	switch d {
+	case "string", "int", "float", "bool", "true", "false", "null", "array", "void", "resource", "callable", "iterable":
+		return true
+	}
+	return strings.HasSuffix(d, "[]")
+}
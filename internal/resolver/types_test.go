@@ -0,0 +1,125 @@
+package resolver
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/peter/wpdocs/internal/model"
+)
+
+// nativeType builds a native type from PHP syntax such as "?int",
+// "WP_Post|false" or "(A&B)|null". It handles what the tests need.
+func nativeType(s string) model.TypeExpr {
+	var members []model.TypeExpr
+	if strings.HasPrefix(s, "?") {
+		s = s[1:] + "|null"
+	}
+	for _, m := range strings.Split(s, "|") {
+		if strings.Contains(m, "&") {
+			inter := model.TypeExpr{Kind: model.TypeIntersection}
+			for _, part := range strings.Split(strings.Trim(m, "()"), "&") {
+				inter.Types = append(inter.Types, model.TypeExpr{Kind: model.TypeNamed, Name: part})
+			}
+			members = append(members, inter)
+			continue
+		}
+		members = append(members, model.TypeExpr{Kind: model.TypeNamed, Name: m})
+	}
+	if len(members) == 1 {
+		return members[0]
+	}
+	return model.TypeExpr{Kind: model.TypeUnion, Types: members}
+}
+
+func TestSplitDocType(t *testing.T) {
+	tests := []struct {
+		doc  string
+		want []string
+	}{
+		{"", nil},
+		{"int", []string{"int"}},
+		{"Integer|Boolean", []string{"int", "bool"}},
+		{"?\\WP_Post", []string{"null", "wp_post"}},
+		{"array<string|int, WP_Post>|false", []string{"array<string|int, wp_post>", "false"}},
+		{"array{ key: int|string }|null", []string{"array{ key: int|string }", "null"}},
+		{"callback|$this", []string{"callable", "static"}},
+	}
+	for _, tt := range tests {
+		if got := splitDocType(tt.doc); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitDocType(%q) = %q, want %q", tt.doc, got, tt.want)
+		}
+	}
+}
+
+func TestTypesAgree(t *testing.T) {
+	tests := []struct {
+		native, doc  string
+		class        string
+		implicitNull bool
+		want         bool
+	}{
+		{native: "int", doc: "int", want: true},
+		{native: "int", doc: "integer", want: true},
+		{native: "int", doc: "string", want: false},
+		{native: "?int", doc: "int|null", want: true},
+		{native: "?int", doc: "int", want: false},
+		{native: "int", doc: "int|null", want: false},
+		{native: "int", doc: "int|null", implicitNull: true, want: true},
+		{native: "array", doc: "WP_Post[]", want: true},
+		{native: "array", doc: "array<string, int>", want: true},
+		{native: "array", doc: "string", want: false},
+		{native: "iterable", doc: "array", want: true},
+		{native: "bool", doc: "true|false", want: true},
+		{native: "false", doc: "bool", want: true},
+		{native: "WP_Post|false", doc: "WP_Post|false", want: true},
+		{native: "\\Foo\\WP_Post", doc: "WP_Post", want: true},
+		{native: "static", doc: "WP_Query", class: "WP_Query", want: true},
+		{native: "self", doc: "$this", want: true},
+		{native: "object", doc: "WP_Post", want: true},
+		{native: "object", doc: "string", want: false},
+		{native: "callable", doc: "Closure", want: true},
+		{native: "string", doc: "class-string", want: true},
+		{native: "int", doc: "positive-int", want: true},
+		{native: "(A&B)|null", doc: "A|null", want: true},
+		{native: "mixed", doc: "string", want: true},
+		{native: "string", doc: "mixed", want: true},
+		{native: "string", doc: "", want: true},
+	}
+	for _, tt := range tests {
+		got := typesAgree(nativeType(tt.native), tt.doc, tt.class, tt.implicitNull)
+		if got != tt.want {
+			t.Errorf("typesAgree(%s, %q, %q, %v) = %v, want %v", tt.native, tt.doc, tt.class, tt.implicitNull, got, tt.want)
+		}
+	}
+}
+
+func TestCheckTypeMismatches(t *testing.T) {
+	reg := model.NewRegistry()
+	reg.Add(&model.Symbol{
+		ID:       "f",
+		Kind:     model.KindFunction,
+		Language: "php",
+		Params: []model.Param{
+			{Name: "ok", Native: &model.TypeExpr{Kind: model.TypeNamed, Name: "int"}, DocType: "int"},
+			{Name: "bad", Native: &model.TypeExpr{Kind: model.TypeNamed, Name: "int"}, DocType: "string"},
+			{Name: "undocumented", Native: &model.TypeExpr{Kind: model.TypeNamed, Name: "int"}},
+		},
+		Returns:  &model.ReturnValue{Type: "string", Native: &model.TypeExpr{Kind: model.TypeNamed, Name: "void"}},
+		Location: model.SourceLocation{File: "f.php", StartLine: 3},
+	})
+	r := New(reg)
+	r.checkTypeMismatches()
+
+	var got []string
+	for _, issue := range r.Issues() {
+		got = append(got, issue.Message)
+	}
+	want := []string{
+		`parameter $bad: documented "string", declared "int"`,
+		`return: documented "string", declared "void"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("issues = %q, want %q", got, want)
+	}
+}